@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/schema"
 )
 
@@ -13,7 +15,13 @@ type (
 	Ident = schema.Ident
 )
 
-type NullTime = schema.NullTime
+type (
+	NullTime    = schema.NullTime
+	NullString  = schema.NullString
+	NullInt64   = schema.NullInt64
+	NullFloat64 = schema.NullFloat64
+	NullBool    = schema.NullBool
+)
 
 type BaseModel = schema.BaseModel
 
@@ -120,3 +128,54 @@ func appendIn(fmter schema.Formatter, b []byte, slice reflect.Value) []byte {
 	}
 	return b
 }
+
+//------------------------------------------------------------------------------
+
+// CastValue is a query appender returned by Cast.
+type CastValue struct {
+	expr string
+	typ  string
+}
+
+var _ schema.QueryAppender = CastValue{}
+
+// Cast returns `CAST(expr AS typ)`. Both the expression and the type are appended
+// as is, so they must not contain user input. On MySQL integer and text types
+// are replaced with SIGNED and CHAR that MySQL supports in CAST.
+//
+//	q.Where("? > 0", bun.Cast("price", "int"))
+func Cast(expr, typ string) CastValue {
+	return CastValue{
+		expr: expr,
+		typ:  typ,
+	}
+}
+
+func (c CastValue) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	typ := c.typ
+	switch fmter.Dialect().Name() {
+	case dialect.MySQL5, dialect.MySQL8:
+		typ = mysqlCastType(typ)
+	}
+
+	b = append(b, "CAST("...)
+	b = append(b, c.expr...)
+	b = append(b, " AS "...)
+	b = append(b, typ...)
+	b = append(b, ')')
+	return b, nil
+}
+
+func mysqlCastType(typ string) string {
+	lower := strings.ToLower(typ)
+	switch lower {
+	case "int", "integer", "smallint", "bigint", "int4", "int8":
+		return "SIGNED"
+	case "text", "varchar":
+		return "CHAR"
+	}
+	if strings.HasPrefix(lower, "varchar(") {
+		return "CHAR" + typ[len("varchar"):]
+	}
+	return typ
+}
@@ -0,0 +1,202 @@
+// Package bunerr normalizes constraint violation errors returned by
+// pgdriver, pgx, lib/pq, go-sql-driver/mysql, and SQLite drivers.
+//
+// The package does not import the drivers. It recognizes their errors by
+// methods and fields, so it works with any of them without adding dependencies.
+package bunerr
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+type violation int
+
+const (
+	noViolation violation = iota
+	uniqueViolation
+	notNullViolation
+	foreignKeyViolation
+)
+
+// IsUniqueViolation reports whether err is caused by a unique or
+// primary key constraint violation.
+func IsUniqueViolation(err error) bool {
+	v, _ := inspect(err)
+	return v == uniqueViolation
+}
+
+// IsNotNullViolation reports whether err is caused by a NOT NULL
+// constraint violation.
+func IsNotNullViolation(err error) bool {
+	v, _ := inspect(err)
+	return v == notNullViolation
+}
+
+// IsForeignKeyViolation reports whether err is caused by a foreign key
+// constraint violation.
+func IsForeignKeyViolation(err error) bool {
+	v, _ := inspect(err)
+	return v == foreignKeyViolation
+}
+
+// ConstraintName returns the name of the violated constraint or, on MySQL,
+// of the unique index. SQLite does not report constraint names.
+func ConstraintName(err error) (string, bool) {
+	v, name := inspect(err)
+	if v == noViolation || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+func inspect(err error) (violation, string) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if v, name, ok := inspectDriverError(err); ok {
+			return v, name
+		}
+	}
+	return noViolation, ""
+}
+
+// pgdriver.Error
+type pgFieldError interface {
+	Field(k byte) string
+}
+
+// pgconn.PgError and pq.Error
+type pgStateError interface {
+	SQLState() string
+}
+
+// modernc.org/sqlite Error returns the extended result code.
+type sqliteCodeError interface {
+	Code() int
+}
+
+func inspectDriverError(err error) (violation, string, bool) {
+	switch e := err.(type) {
+	case pgFieldError:
+		return pgViolation(e.Field('C')), e.Field('n'), true
+	case pgStateError:
+		name := stringField(err, "ConstraintName")
+		if name == "" {
+			name = stringField(err, "Constraint")
+		}
+		return pgViolation(e.SQLState()), name, true
+	case sqliteCodeError:
+		return sqliteViolation(e.Code()), "", true
+	}
+
+	// github.com/go-sql-driver/mysql MySQLError
+	if f, ok := field(err, "Number"); ok && isUint(f.Kind()) {
+		number := f.Uint()
+		v := mysqlViolation(number)
+		return v, mysqlConstraintName(v, stringField(err, "Message")), true
+	}
+	// github.com/mattn/go-sqlite3 Error
+	if f, ok := field(err, "ExtendedCode"); ok && isInt(f.Kind()) {
+		return sqliteViolation(int(f.Int())), "", true
+	}
+
+	return noViolation, "", false
+}
+
+func pgViolation(code string) violation {
+	switch code {
+	case "23505":
+		return uniqueViolation
+	case "23502":
+		return notNullViolation
+	case "23503":
+		return foreignKeyViolation
+	default:
+		return noViolation
+	}
+}
+
+func mysqlViolation(number uint64) violation {
+	switch number {
+	case 1062, 1586: // ER_DUP_ENTRY, ER_DUP_ENTRY_WITH_KEY_NAME
+		return uniqueViolation
+	case 1048, 1364: // ER_BAD_NULL_ERROR, ER_NO_DEFAULT_FOR_FIELD
+		return notNullViolation
+	case 1216, 1217, 1451, 1452: // ER_NO_REFERENCED_ROW, ER_ROW_IS_REFERENCED
+		return foreignKeyViolation
+	default:
+		return noViolation
+	}
+}
+
+func sqliteViolation(code int) violation {
+	switch code {
+	case 2067, 1555: // SQLITE_CONSTRAINT_UNIQUE, SQLITE_CONSTRAINT_PRIMARYKEY
+		return uniqueViolation
+	case 1299: // SQLITE_CONSTRAINT_NOTNULL
+		return notNullViolation
+	case 787: // SQLITE_CONSTRAINT_FOREIGNKEY
+		return foreignKeyViolation
+	default:
+		return noViolation
+	}
+}
+
+var (
+	mysqlDupKeyRE     = regexp.MustCompile(`for key '([^']+)'`)
+	mysqlConstraintRE = regexp.MustCompile("CONSTRAINT `([^`]+)`")
+)
+
+func mysqlConstraintName(v violation, msg string) string {
+	switch v {
+	case uniqueViolation:
+		if m := mysqlDupKeyRE.FindStringSubmatch(msg); m != nil {
+			// MySQL 8 prefixes the key with the table name.
+			name := m[1]
+			if i := strings.LastIndexByte(name, '.'); i >= 0 {
+				name = name[i+1:]
+			}
+			return name
+		}
+	case foreignKeyViolation:
+		if m := mysqlConstraintRE.FindStringSubmatch(msg); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+//------------------------------------------------------------------------------
+
+func field(err error, name string) (reflect.Value, bool) {
+	v := reflect.Indirect(reflect.ValueOf(err))
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	f := v.FieldByName(name)
+	return f, f.IsValid()
+}
+
+func stringField(err error, name string) string {
+	if f, ok := field(err, name); ok && f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+func isUint(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isInt(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
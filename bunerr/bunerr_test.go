@@ -0,0 +1,86 @@
+package bunerr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pgError struct {
+	m map[byte]string
+}
+
+func (err pgError) Field(k byte) string { return err.m[k] }
+func (err pgError) Error() string       { return err.m['M'] }
+
+type mysqlError struct {
+	Number  uint16
+	Message string
+}
+
+func (err *mysqlError) Error() string { return err.Message }
+
+type sqliteError struct {
+	Code         int
+	ExtendedCode int
+}
+
+func (err sqliteError) Error() string { return "constraint failed" }
+
+func TestConstraintErrors(t *testing.T) {
+	type Test struct {
+		err        error
+		unique     bool
+		notNull    bool
+		foreignKey bool
+		constraint string
+	}
+
+	tests := []Test{
+		{
+			err:        pgError{m: map[byte]string{'C': "23505", 'n': "users_email_key"}},
+			unique:     true,
+			constraint: "users_email_key",
+		},
+		{
+			err:     pgError{m: map[byte]string{'C': "23502"}},
+			notNull: true,
+		},
+		{
+			err: fmt.Errorf("insert: %w", &mysqlError{
+				Number:  1062,
+				Message: "Duplicate entry 'a' for key 'users.users_email_key'",
+			}),
+			unique:     true,
+			constraint: "users_email_key",
+		},
+		{
+			err: &mysqlError{
+				Number: 1452,
+				Message: "Cannot add or update a child row: a foreign key constraint fails " +
+					"(`test`.`posts`, CONSTRAINT `posts_user_id_fkey` FOREIGN KEY (`user_id`) " +
+					"REFERENCES `users` (`id`))",
+			},
+			foreignKey: true,
+			constraint: "posts_user_id_fkey",
+		},
+		{
+			err:        sqliteError{Code: 19, ExtendedCode: 787},
+			foreignKey: true,
+		},
+		{
+			err: fmt.Errorf("not a driver error"),
+		},
+	}
+
+	for i, test := range tests {
+		require.Equal(t, test.unique, IsUniqueViolation(test.err), "#%d", i)
+		require.Equal(t, test.notNull, IsNotNullViolation(test.err), "#%d", i)
+		require.Equal(t, test.foreignKey, IsForeignKeyViolation(test.err), "#%d", i)
+
+		name, ok := ConstraintName(test.err)
+		require.Equal(t, test.constraint, name, "#%d", i)
+		require.Equal(t, test.constraint != "", ok, "#%d", i)
+	}
+}
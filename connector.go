@@ -0,0 +1,178 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+)
+
+// ConnectHook is called with each new connection before it is added to the pool,
+// for example, to execute `SET search_path` or `SET application_name`.
+type ConnectHook func(ctx context.Context, conn *sql.Conn) error
+
+// Connector wraps a driver.Connector and calls the connect hooks once for each
+// connection it opens. Use it with sql.OpenDB:
+//
+//	sqldb := sql.OpenDB(bun.NewConnector(pgdriver.NewConnector(pgdriver.WithDSN(dsn))))
+//	db := bun.NewDB(sqldb, pgdialect.New())
+//	db.AddConnectHook(func(ctx context.Context, conn *sql.Conn) error {
+//		_, err := conn.ExecContext(ctx, "SET application_name = 'myapp'")
+//		return err
+//	})
+type Connector struct {
+	connector driver.Connector
+
+	mu    sync.RWMutex
+	hooks []ConnectHook
+}
+
+var _ driver.Connector = (*Connector)(nil)
+
+func NewConnector(connector driver.Connector) *Connector {
+	return &Connector{
+		connector: connector,
+	}
+}
+
+// AddConnectHook adds a hook that is called in registration order for every
+// new connection. If a hook returns an error, the connection is closed
+// and the error is returned to the query that needed the connection.
+// Connections that are already in the pool are not affected.
+func (c *Connector) AddConnectHook(hook ConnectHook) {
+	c.mu.Lock()
+	c.hooks = append(c.hooks, hook)
+	c.mu.Unlock()
+}
+
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	hooks := c.hooks
+	c.mu.RUnlock()
+
+	if len(hooks) == 0 {
+		return conn, nil
+	}
+
+	if err := runConnectHooks(ctx, conn, hooks); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Driver returns the driver of the wrapped connector. The driver is wrapped
+// so DB.AddConnectHook can find the connector of *sql.DB.
+func (c *Connector) Driver() driver.Driver {
+	return connectorDriver{
+		Driver:    c.connector.Driver(),
+		connector: c,
+	}
+}
+
+type connectorDriver struct {
+	driver.Driver
+	connector *Connector
+}
+
+// runConnectHooks passes the driver connection to the hooks as *sql.Conn
+// using a temporary *sql.DB that does not close the connection.
+func runConnectHooks(ctx context.Context, conn driver.Conn, hooks []ConnectHook) error {
+	db := sql.OpenDB(singleConnector{conn: conn})
+	defer db.Close()
+
+	sqlConn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer sqlConn.Close()
+
+	for _, hook := range hooks {
+		if err := hook(ctx, sqlConn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type singleConnector struct {
+	conn driver.Conn
+}
+
+func (c singleConnector) Connect(context.Context) (driver.Conn, error) {
+	return hookConn{c.conn}, nil
+}
+
+func (c singleConnector) Driver() driver.Driver {
+	return nil
+}
+
+// hookConn leaves the connection open when the temporary *sql.DB is closed.
+type hookConn struct {
+	driver.Conn
+}
+
+var (
+	_ driver.ExecerContext      = hookConn{}
+	_ driver.QueryerContext     = hookConn{}
+	_ driver.ConnPrepareContext = hookConn{}
+	_ driver.ConnBeginTx        = hookConn{}
+	_ driver.NamedValueChecker  = hookConn{}
+)
+
+func (c hookConn) Close() error {
+	return nil
+}
+
+func (c hookConn) ExecContext(
+	ctx context.Context, query string, args []driver.NamedValue,
+) (driver.Result, error) {
+	if execer, ok := c.Conn.(driver.ExecerContext); ok {
+		return execer.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c hookConn) QueryContext(
+	ctx context.Context, query string, args []driver.NamedValue,
+) (driver.Rows, error) {
+	if queryer, ok := c.Conn.(driver.QueryerContext); ok {
+		return queryer.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c hookConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c hookConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	// The same checks as database/sql does for drivers without ConnBeginTx.
+	if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) {
+		return nil, errors.New("bun: driver does not support non-default isolation level")
+	}
+	if opts.ReadOnly {
+		return nil, errors.New("bun: driver does not support read-only transactions")
+	}
+	return c.Conn.Begin() //nolint:staticcheck
+}
+
+func (c hookConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	// Use the default conversion.
+	return driver.ErrSkip
+}
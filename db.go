@@ -3,11 +3,14 @@ package bun
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/internal"
@@ -16,6 +19,18 @@ import (
 
 const (
 	discardUnknownColumns internal.Flag = 1 << iota
+	nullPolicyError
+)
+
+// NullPolicy controls what happens when a NULL is scanned into a model field
+// that can't represent NULL, for example, a string.
+type NullPolicy int
+
+const (
+	// NullPolicyIgnore scans NULL as a zero value. This is the default.
+	NullPolicyIgnore NullPolicy = iota
+	// NullPolicyError returns an error instead.
+	NullPolicyError
 )
 
 type DBStats struct {
@@ -41,6 +56,10 @@ type DB struct {
 	fmter schema.Formatter
 	flags internal.Flag
 
+	fallbackReplica *DB
+
+	aclRoles map[string]struct{}
+
 	stats DBStats
 }
 
@@ -128,6 +147,14 @@ func (db *DB) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(db)
 }
 
+func (db *DB) NewCreateSchema(name string) *CreateSchemaQuery {
+	return NewCreateSchemaQuery(db, name)
+}
+
+func (db *DB) NewDropSchema(name string) *DropSchemaQuery {
+	return NewDropSchemaQuery(db, name)
+}
+
 func (db *DB) ResetModel(ctx context.Context, models ...interface{}) error {
 	for _, model := range models {
 		if _, err := db.NewDropTable().Model(model).IfExists().Exec(ctx); err != nil {
@@ -168,10 +195,76 @@ func (db *DB) ScanRow(ctx context.Context, rows *sql.Rows, dest ...interface{})
 	return rs.ScanRow(ctx, rows)
 }
 
+// nopDB resolves models for ModelFromRows and ModelSlice, which are not bound to a DB.
+var nopDB = NewDB(nil, schema.DefaultNopDialect())
+
+// ModelFromRows scans rows into the model like DB.ScanRows, but does not
+// require a DB, so it can be used with rows returned by database/sql directly.
+// Dialect-specific types, for example, PostgreSQL arrays, must implement
+// sql.Scanner to be scanned. The caller is responsible for closing rows.
+func ModelFromRows(ctx context.Context, rows *sql.Rows, model interface{}) error {
+	return nopDB.ScanRows(ctx, rows, model)
+}
+
+// PingWithTimeout verifies the connection to the database like PingContext
+// using a context that is canceled after the timeout.
+func (db *DB) PingWithTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
 func (db *DB) AddQueryHook(hook QueryHook) {
 	db.queryHooks = append(db.queryHooks, hook)
 }
 
+// AddConnectHook adds a hook that is called in registration order whenever
+// the pool opens a new connection. The *sql.DB must be opened with a Connector,
+// for example, sql.OpenDB(bun.NewConnector(connector)). The hooks are shared
+// by all DBs that use the same *sql.DB.
+func (db *DB) AddConnectHook(hook ConnectHook) {
+	d, ok := db.sqlDB().Driver().(connectorDriver)
+	if !ok {
+		panic("bun: AddConnectHook requires *sql.DB opened with bun.NewConnector")
+	}
+	d.connector.AddConnectHook(hook)
+}
+
+// SetNullPolicy sets the policy for scanning NULL into non-nullable model fields.
+// Fields that are pointers, slices, maps, interfaces, use the nullzero option,
+// or implement sql.Scanner are always allowed to receive NULL.
+func (db *DB) SetNullPolicy(policy NullPolicy) {
+	switch policy {
+	case NullPolicyError:
+		db.flags = db.flags.Set(nullPolicyError)
+	default:
+		db.flags = db.flags.Remove(nullPolicyError)
+	}
+}
+
+// SetFallbackReplica sets the replica that is used to retry SELECT queries
+// when the primary database can't be reached. Other queries and queries
+// executed in a transaction or on a dedicated connection are never retried.
+// The replica must use the same dialect as the primary. Pass nil to disable the fallback.
+func (db *DB) SetFallbackReplica(replica *DB) {
+	if replica != nil && replica.dialect.Name() != db.dialect.Name() {
+		panic(fmt.Errorf("bun: replica dialect %s does not match %s",
+			replica.dialect.Name(), db.dialect.Name()))
+	}
+	db.fallbackReplica = replica
+}
+
+// isConnError reports whether the error means that the database can't be reached.
+// Other network errors, for example, a read timeout of a slow query, don't mean
+// that the database is down and retrying the query would only add load.
+func isConnError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 func (db *DB) Table(typ reflect.Type) *schema.Table {
 	return db.dialect.Tables().Get(typ)
 }
@@ -195,6 +288,66 @@ func (db *DB) WithNamedArg(name string, value interface{}) *DB {
 	return clone
 }
 
+// WithACL returns a copy of the DB that has the roles of the request
+// the ctx belongs to. Queries built with the copy exclude the columns
+// of fields tagged with `bun:",acl:role"` unless the role is one of the roles.
+// This applies everywhere bun expands model columns: SELECT columns including
+// `*`, joined relations, RETURNING clauses including `RETURNING *`,
+// and the ?Columns and ?TableColumns placeholders.
+func (db *DB) WithACL(ctx context.Context, roles ...string) *DB {
+	clone := db.clone()
+	clone.aclRoles = make(map[string]struct{}, len(roles))
+	for _, role := range roles {
+		clone.aclRoles[role] = struct{}{}
+	}
+	return clone
+}
+
+// canSelect reports whether the roles set with WithACL allow selecting the field.
+func (db *DB) canSelect(field *schema.Field) bool {
+	if db.aclRoles == nil {
+		return true
+	}
+	role, ok := field.Tag.Options["acl"]
+	if !ok {
+		return true
+	}
+	_, ok = db.aclRoles[role]
+	return ok
+}
+
+func (db *DB) selectableFields(fields []*schema.Field) []*schema.Field {
+	if db.aclRoles == nil {
+		return fields
+	}
+
+	selectable := make([]*schema.Field, 0, len(fields))
+	for _, field := range fields {
+		if db.canSelect(field) {
+			selectable = append(selectable, field)
+		}
+	}
+	return selectable
+}
+
+// isACLStar reports whether the column is `*` that must be expanded
+// to the columns allowed by the roles set with WithACL.
+func (db *DB) isACLStar(col schema.QueryWithArgs) bool {
+	return db.aclRoles != nil && len(col.Args) == 0 && col.Query == "*"
+}
+
+// WithDialect returns a copy of the DB that uses the dialect to generate queries.
+// The copy shares the underlying *sql.DB with the original DB.
+func (db *DB) WithDialect(dialect schema.Dialect) *DB {
+	dialect.Init(db.DB)
+
+	clone := db.clone()
+	clone.dialect = dialect
+	clone.features = dialect.Features()
+	clone.fmter = clone.fmter.WithDialect(dialect)
+	return clone
+}
+
 func (db *DB) NamedArg(name string) interface{} {
 	return db.fmter.Arg(name)
 }
@@ -213,7 +366,7 @@ func (db *DB) ExecContext(
 	ctx context.Context, query string, args ...interface{},
 ) (sql.Result, error) {
 	ctx, event := db.beforeQuery(ctx, nil, query, args)
-	res, err := db.DB.ExecContext(ctx, db.format(query, args))
+	res, err := db.execContext(ctx, db.sqlDB(), query, args)
 	db.afterQuery(ctx, event, res, err)
 	return res, err
 }
@@ -226,7 +379,7 @@ func (db *DB) QueryContext(
 	ctx context.Context, query string, args ...interface{},
 ) (*sql.Rows, error) {
 	ctx, event := db.beforeQuery(ctx, nil, query, args)
-	rows, err := db.DB.QueryContext(ctx, db.format(query, args))
+	rows, err := db.queryContext(ctx, db.sqlDB(), query, args)
 	db.afterQuery(ctx, event, nil, err)
 	return rows, err
 }
@@ -237,15 +390,80 @@ func (db *DB) QueryRow(query string, args ...interface{}) *sql.Row {
 
 func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	ctx, event := db.beforeQuery(ctx, nil, query, args)
-	row := db.DB.QueryRowContext(ctx, db.format(query, args))
+	row := db.queryRowContext(ctx, db.sqlDB(), query, args)
 	db.afterQuery(ctx, event, nil, row.Err())
 	return row
 }
 
+const noSQLDBMsg = "bun: DB was created without *sql.DB and can only be used to generate queries"
+
+func (db *DB) sqlDB() *sql.DB {
+	if db.DB == nil {
+		panic(noSQLDBMsg)
+	}
+	return db.DB
+}
+
 func (db *DB) format(query string, args []interface{}) string {
 	return db.fmter.FormatQuery(query, args...)
 }
 
+func (db *DB) execContext(
+	ctx context.Context, conn IConn, query string, args []interface{},
+) (sql.Result, error) {
+	conn, release, err := db.tenantConn(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	formattedQuery := db.format(query, args)
+	res, err := conn.ExecContext(ctx, formattedQuery)
+	if err != nil {
+		return nil, newRawQueryError(err, formattedQuery, args)
+	}
+	return res, nil
+}
+
+func (db *DB) queryContext(
+	ctx context.Context, conn IConn, query string, args []interface{},
+) (*sql.Rows, error) {
+	if _, ok := TenantSchema(ctx); ok {
+		if _, ok := conn.(*sql.Tx); !ok {
+			return nil, errTenantRows
+		}
+	}
+	// The release func is a no-op for transactions.
+	conn, _, err := db.tenantConn(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	formattedQuery := db.format(query, args)
+	rows, err := conn.QueryContext(ctx, formattedQuery)
+	if err != nil {
+		return nil, newRawQueryError(err, formattedQuery, args)
+	}
+	return rows, nil
+}
+
+// queryRowContext applies the tenant schema only in transactions, because
+// other connections can't be reset before the row is scanned.
+func (db *DB) queryRowContext(
+	ctx context.Context, conn IConn, query string, args []interface{},
+) *sql.Row {
+	if _, ok := TenantSchema(ctx); ok {
+		tx, ok := conn.(*sql.Tx)
+		if !ok {
+			return errRow(errTenantRows)
+		}
+		if _, _, err := db.tenantConn(ctx, tx); err != nil {
+			return errRow(err)
+		}
+	}
+	return conn.QueryRowContext(ctx, db.format(query, args))
+}
+
 //------------------------------------------------------------------------------
 
 type Conn struct {
@@ -254,7 +472,7 @@ type Conn struct {
 }
 
 func (db *DB) Conn(ctx context.Context) (Conn, error) {
-	conn, err := db.DB.Conn(ctx)
+	conn, err := db.sqlDB().Conn(ctx)
 	if err != nil {
 		return Conn{}, err
 	}
@@ -268,7 +486,7 @@ func (c Conn) ExecContext(
 	ctx context.Context, query string, args ...interface{},
 ) (sql.Result, error) {
 	ctx, event := c.db.beforeQuery(ctx, nil, query, args)
-	res, err := c.Conn.ExecContext(ctx, c.db.format(query, args))
+	res, err := c.db.execContext(ctx, c.Conn, query, args)
 	c.db.afterQuery(ctx, event, res, err)
 	return res, err
 }
@@ -277,14 +495,14 @@ func (c Conn) QueryContext(
 	ctx context.Context, query string, args ...interface{},
 ) (*sql.Rows, error) {
 	ctx, event := c.db.beforeQuery(ctx, nil, query, args)
-	rows, err := c.Conn.QueryContext(ctx, c.db.format(query, args))
+	rows, err := c.db.queryContext(ctx, c.Conn, query, args)
 	c.db.afterQuery(ctx, event, nil, err)
 	return rows, err
 }
 
 func (c Conn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	ctx, event := c.db.beforeQuery(ctx, nil, query, args)
-	row := c.Conn.QueryRowContext(ctx, c.db.format(query, args))
+	row := c.db.queryRowContext(ctx, c.Conn, query, args)
 	c.db.afterQuery(ctx, event, nil, row.Err())
 	return row
 }
@@ -337,6 +555,14 @@ func (c Conn) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(c.db).Conn(c)
 }
 
+func (c Conn) NewCreateSchema(name string) *CreateSchemaQuery {
+	return NewCreateSchemaQuery(c.db, name).Conn(c)
+}
+
+func (c Conn) NewDropSchema(name string) *DropSchemaQuery {
+	return NewDropSchemaQuery(c.db, name).Conn(c)
+}
+
 //------------------------------------------------------------------------------
 
 type Stmt struct {
@@ -348,7 +574,7 @@ func (db *DB) Prepare(query string) (Stmt, error) {
 }
 
 func (db *DB) PrepareContext(ctx context.Context, query string) (Stmt, error) {
-	stmt, err := db.DB.PrepareContext(ctx, query)
+	stmt, err := db.sqlDB().PrepareContext(ctx, query)
 	if err != nil {
 		return Stmt{}, err
 	}
@@ -373,18 +599,33 @@ func (db *DB) RunInTx(
 	}
 	defer tx.Rollback() //nolint:errcheck
 
+	level := sql.LevelDefault
+	if opts != nil {
+		level = opts.Isolation
+	}
+	ctx = context.WithValue(ctx, txIsolationLevelKey{}, level)
+
 	if err := fn(ctx, tx); err != nil {
 		return err
 	}
 	return tx.Commit()
 }
 
+type txIsolationLevelKey struct{}
+
+// TransactionIsolationLevel returns the isolation level of the transaction
+// started by RunInTx. It returns false if the context does not belong to RunInTx.
+func (db *DB) TransactionIsolationLevel(ctx context.Context) (sql.IsolationLevel, bool) {
+	level, ok := ctx.Value(txIsolationLevelKey{}).(sql.IsolationLevel)
+	return level, ok
+}
+
 func (db *DB) Begin() (Tx, error) {
 	return db.BeginTx(context.Background(), nil)
 }
 
 func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
-	tx, err := db.DB.BeginTx(ctx, opts)
+	tx, err := db.sqlDB().BeginTx(ctx, opts)
 	if err != nil {
 		return Tx{}, err
 	}
@@ -402,7 +643,7 @@ func (tx Tx) ExecContext(
 	ctx context.Context, query string, args ...interface{},
 ) (sql.Result, error) {
 	ctx, event := tx.db.beforeQuery(ctx, nil, query, args)
-	res, err := tx.Tx.ExecContext(ctx, tx.db.format(query, args))
+	res, err := tx.db.execContext(ctx, tx.Tx, query, args)
 	tx.db.afterQuery(ctx, event, res, err)
 	return res, err
 }
@@ -415,7 +656,7 @@ func (tx Tx) QueryContext(
 	ctx context.Context, query string, args ...interface{},
 ) (*sql.Rows, error) {
 	ctx, event := tx.db.beforeQuery(ctx, nil, query, args)
-	rows, err := tx.Tx.QueryContext(ctx, tx.db.format(query, args))
+	rows, err := tx.db.queryContext(ctx, tx.Tx, query, args)
 	tx.db.afterQuery(ctx, event, nil, err)
 	return rows, err
 }
@@ -426,7 +667,7 @@ func (tx Tx) QueryRow(query string, args ...interface{}) *sql.Row {
 
 func (tx Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	ctx, event := tx.db.beforeQuery(ctx, nil, query, args)
-	row := tx.Tx.QueryRowContext(ctx, tx.db.format(query, args))
+	row := tx.db.queryRowContext(ctx, tx.Tx, query, args)
 	tx.db.afterQuery(ctx, event, nil, row.Err())
 	return row
 }
@@ -481,6 +722,14 @@ func (tx Tx) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(tx.db).Conn(tx)
 }
 
+func (tx Tx) NewCreateSchema(name string) *CreateSchemaQuery {
+	return NewCreateSchemaQuery(tx.db, name).Conn(tx)
+}
+
+func (tx Tx) NewDropSchema(name string) *DropSchemaQuery {
+	return NewDropSchemaQuery(tx.db, name).Conn(tx)
+}
+
 //------------------------------------------------------------------------------0
 
 func (db *DB) makeQueryBytes() []byte {
@@ -19,4 +19,27 @@ const (
 	TableIdentity
 	TableTruncate
 	OnDuplicateKey
+	TableInherits
+	Lateral       // LATERAL subqueries and joins
+	WithRecursive // WITH RECURSIVE common table expressions
+	CTE           // WITH common table expressions
+	Window        // window functions and the WINDOW clause
+	ILike         // ILIKE operator
+	RowsFrom      // ROWS FROM table functions
+	TableSample   // TABLESAMPLE clause
+	RowLock       // FOR UPDATE row-level locks
+	LockForShare  // FOR SHARE row-level locks
+	LockOf        // FOR ... OF tables
+	LockKey       // FOR NO KEY UPDATE and FOR KEY SHARE row-level locks
+	LockNoWait    // NOWAIT and SKIP LOCKED
+	SetLocal      // SET LOCAL transaction-scoped settings
+	SearchPath    // search_path to resolve unqualified table names
+	Schema        // CREATE SCHEMA and DROP SCHEMA
+	PosixRegexp   // ~ and ~* POSIX regular expression operators
+	Regexp        // REGEXP operator
+	RegexpLike    // REGEXP_LIKE function
+	JSONOperators // -> and ->> JSON operators
+	JSONUnquote   // JSON_UNQUOTE to extract JSON scalars as text
+	Rand          // RAND() instead of RANDOM()
+	FieldFunc     // FIELD() function
 )
@@ -37,7 +37,12 @@ func New() *Dialect {
 		feature.UpdateMultiTable |
 		feature.ValuesRow |
 		feature.TableTruncate |
-		feature.OnDuplicateKey
+		feature.OnDuplicateKey |
+		feature.RowLock |
+		feature.Regexp |
+		feature.JSONUnquote |
+		feature.Rand |
+		feature.FieldFunc
 	return d
 }
 
@@ -51,7 +56,15 @@ func (d *Dialect) Init(db *sql.DB) {
 	version = semver.MajorMinor("v" + cleanupVersion(version))
 	if semver.Compare(version, "v8.0") >= 0 {
 		d.name = dialect.MySQL8
-		d.features |= feature.DeleteTableAlias
+		d.features |= feature.DeleteTableAlias |
+			feature.Lateral |
+			feature.WithRecursive |
+			feature.CTE |
+			feature.Window |
+			feature.LockForShare |
+			feature.LockOf |
+			feature.LockNoWait |
+			feature.RegexpLike
 	}
 }
 
@@ -70,6 +83,10 @@ func (d *Dialect) Features() feature.Feature {
 	return d.features
 }
 
+func (d *Dialect) SupportsFeature(f feature.Feature) bool {
+	return d.features.Has(f)
+}
+
 func (d *Dialect) Tables() *schema.Tables {
 	return d.tables
 }
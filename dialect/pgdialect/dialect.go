@@ -19,6 +19,7 @@ type Dialect struct {
 
 	appenderMap sync.Map
 	scannerMap  sync.Map
+	enumMap     sync.Map
 }
 
 func New() *Dialect {
@@ -31,7 +32,25 @@ func New() *Dialect {
 		feature.DeleteTableAlias |
 		feature.TableCascade |
 		feature.TableIdentity |
-		feature.TableTruncate
+		feature.TableTruncate |
+		feature.TableInherits |
+		feature.Lateral |
+		feature.WithRecursive |
+		feature.CTE |
+		feature.Window |
+		feature.ILike |
+		feature.RowsFrom |
+		feature.TableSample |
+		feature.RowLock |
+		feature.LockForShare |
+		feature.LockOf |
+		feature.LockKey |
+		feature.LockNoWait |
+		feature.SetLocal |
+		feature.SearchPath |
+		feature.Schema |
+		feature.PosixRegexp |
+		feature.JSONOperators
 	return d
 }
 
@@ -45,6 +64,10 @@ func (d *Dialect) Features() feature.Feature {
 	return d.features
 }
 
+func (d *Dialect) SupportsFeature(f feature.Feature) bool {
+	return d.features.Has(f)
+}
+
 func (d *Dialect) Tables() *schema.Tables {
 	return d.tables
 }
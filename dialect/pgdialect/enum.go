@@ -0,0 +1,29 @@
+package pgdialect
+
+// EnumType describes the values of a PostgreSQL enum type.
+type EnumType struct {
+	Values []string
+}
+
+// Enum returns an enum type with the values in the sort order of the type.
+func Enum(values ...string) EnumType {
+	return EnumType{Values: values}
+}
+
+// RegisterEnum registers the enum type with the name. Fields tagged with
+// `bun:",pgenum:name"` use the type as the column type and CreateTableQuery
+// creates the type before the table. DropTableQuery drops the type only when
+// WithEnumTypes is used. Types that are not registered must already exist
+// in the database.
+func (d *Dialect) RegisterEnum(name string, enum EnumType) {
+	d.enumMap.Store(name, enum.Values)
+}
+
+// EnumValues returns the values of the enum type registered with RegisterEnum.
+func (d *Dialect) EnumValues(name string) ([]string, bool) {
+	v, ok := d.enumMap.Load(name)
+	if !ok {
+		return nil, false
+	}
+	return v.([]string), true
+}
@@ -0,0 +1,31 @@
+package pgdialect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnum(t *testing.T) {
+	d := New()
+	d.RegisterEnum("mood", Enum("sad", "ok", "happy"))
+
+	values, ok := d.EnumValues("mood")
+	if !ok {
+		t.Fatal("enum mood is not registered")
+	}
+	if want := []string{"sad", "ok", "happy"}; !reflect.DeepEqual(values, want) {
+		t.Fatalf("got %v, wanted %v", values, want)
+	}
+
+	if _, ok := d.EnumValues("color"); ok {
+		t.Fatal("enum color is registered")
+	}
+
+	type Model struct {
+		Mood string `bun:",pgenum:mood"`
+	}
+	table := d.Tables().Get(reflect.TypeOf(Model{}))
+	if got := table.FieldMap["mood"].CreateTableSQLType; got != "mood" {
+		t.Fatalf("got %q, wanted mood", got)
+	}
+}
@@ -0,0 +1,333 @@
+package pgdialect
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	pgTypeInt4Range = "INT4RANGE"
+	pgTypeTsRange   = "TSRANGE"
+	pgTypeDateRange = "DATERANGE"
+
+	tsRangeFormat   = "2006-01-02 15:04:05.999999"
+	dateRangeFormat = "2006-01-02"
+)
+
+var (
+	int4RangeType = reflect.TypeOf((*Int4Range)(nil)).Elem()
+	tsRangeType   = reflect.TypeOf((*TsRange)(nil)).Elem()
+	dateRangeType = reflect.TypeOf((*DateRange)(nil)).Elem()
+)
+
+func rangeSQLType(typ reflect.Type) string {
+	switch typ {
+	case int4RangeType:
+		return pgTypeInt4Range
+	case tsRangeType:
+		return pgTypeTsRange
+	case dateRangeType:
+		return pgTypeDateRange
+	}
+	return ""
+}
+
+// RangeBounds describes the bounds of a PostgreSQL range.
+// The zero value describes a range with both bounds exclusive, e.g. (1,5).
+type RangeBounds struct {
+	Empty          bool // the range does not contain any values
+	LowerInclusive bool // [ instead of (
+	UpperInclusive bool // ] instead of )
+	LowerUnbounded bool // lower bound is omitted
+	UpperUnbounded bool // upper bound is omitted
+}
+
+// defaultRangeBounds are the bounds PostgreSQL uses for discrete ranges, i.e. [lower,upper).
+var defaultRangeBounds = RangeBounds{LowerInclusive: true}
+
+//------------------------------------------------------------------------------
+
+// Int4Range represents the PostgreSQL int4range type.
+type Int4Range struct {
+	Lower int32
+	Upper int32
+	RangeBounds
+}
+
+var (
+	_ driver.Valuer = (*Int4Range)(nil)
+	_ sql.Scanner   = (*Int4Range)(nil)
+)
+
+// NewInt4Range returns the range [lower,upper).
+func NewInt4Range(lower, upper int32) Int4Range {
+	return Int4Range{Lower: lower, Upper: upper, RangeBounds: defaultRangeBounds}
+}
+
+func (r Int4Range) Value() (driver.Value, error) {
+	lower := strconv.FormatInt(int64(r.Lower), 10)
+	upper := strconv.FormatInt(int64(r.Upper), 10)
+	return formatRange(lower, upper, r.RangeBounds), nil
+}
+
+func (r *Int4Range) Scan(src interface{}) error {
+	lower, upper, bounds, err := scanRange(src)
+	if err != nil {
+		return err
+	}
+
+	*r = Int4Range{RangeBounds: bounds}
+	if lower != "" {
+		n, err := strconv.ParseInt(lower, 10, 32)
+		if err != nil {
+			return err
+		}
+		r.Lower = int32(n)
+	}
+	if upper != "" {
+		n, err := strconv.ParseInt(upper, 10, 32)
+		if err != nil {
+			return err
+		}
+		r.Upper = int32(n)
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// TsRange represents the PostgreSQL tsrange type, a range of timestamps without time zone.
+type TsRange struct {
+	Lower time.Time
+	Upper time.Time
+	RangeBounds
+}
+
+var (
+	_ driver.Valuer = (*TsRange)(nil)
+	_ sql.Scanner   = (*TsRange)(nil)
+)
+
+// NewTsRange returns the range [lower,upper).
+func NewTsRange(lower, upper time.Time) TsRange {
+	return TsRange{Lower: lower, Upper: upper, RangeBounds: defaultRangeBounds}
+}
+
+func (r TsRange) Value() (driver.Value, error) {
+	lower := r.Lower.UTC().Format(tsRangeFormat)
+	upper := r.Upper.UTC().Format(tsRangeFormat)
+	return formatRange(lower, upper, r.RangeBounds), nil
+}
+
+func (r *TsRange) Scan(src interface{}) error {
+	lower, upper, bounds, err := scanRange(src)
+	if err != nil {
+		return err
+	}
+
+	*r = TsRange{RangeBounds: bounds}
+	if r.Lower, err = parseRangeTime(tsRangeFormat, lower); err != nil {
+		return err
+	}
+	if r.Upper, err = parseRangeTime(tsRangeFormat, upper); err != nil {
+		return err
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// DateRange represents the PostgreSQL daterange type.
+type DateRange struct {
+	Lower time.Time
+	Upper time.Time
+	RangeBounds
+}
+
+var (
+	_ driver.Valuer = (*DateRange)(nil)
+	_ sql.Scanner   = (*DateRange)(nil)
+)
+
+// NewDateRange returns the range [lower,upper).
+func NewDateRange(lower, upper time.Time) DateRange {
+	return DateRange{Lower: lower, Upper: upper, RangeBounds: defaultRangeBounds}
+}
+
+func (r DateRange) Value() (driver.Value, error) {
+	lower := r.Lower.Format(dateRangeFormat)
+	upper := r.Upper.Format(dateRangeFormat)
+	return formatRange(lower, upper, r.RangeBounds), nil
+}
+
+func (r *DateRange) Scan(src interface{}) error {
+	lower, upper, bounds, err := scanRange(src)
+	if err != nil {
+		return err
+	}
+
+	*r = DateRange{RangeBounds: bounds}
+	if r.Lower, err = parseRangeTime(dateRangeFormat, lower); err != nil {
+		return err
+	}
+	if r.Upper, err = parseRangeTime(dateRangeFormat, upper); err != nil {
+		return err
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+func formatRange(lower, upper string, bounds RangeBounds) string {
+	if bounds.Empty {
+		return "empty"
+	}
+
+	b := make([]byte, 0, len(lower)+len(upper)+7)
+
+	if bounds.LowerInclusive && !bounds.LowerUnbounded {
+		b = append(b, '[')
+	} else {
+		b = append(b, '(')
+	}
+	if !bounds.LowerUnbounded {
+		b = appendRangeBound(b, lower)
+	}
+	b = append(b, ',')
+	if !bounds.UpperUnbounded {
+		b = appendRangeBound(b, upper)
+	}
+	if bounds.UpperInclusive && !bounds.UpperUnbounded {
+		b = append(b, ']')
+	} else {
+		b = append(b, ')')
+	}
+
+	return string(b)
+}
+
+func appendRangeBound(b []byte, s string) []byte {
+	if !strings.ContainsAny(s, ` ,()[]"\`) {
+		return append(b, s...)
+	}
+
+	b = append(b, '"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			b = append(b, '\\', c)
+		default:
+			b = append(b, c)
+		}
+	}
+	return append(b, '"')
+}
+
+func scanRange(src interface{}) (lower, upper string, bounds RangeBounds, err error) {
+	switch src := src.(type) {
+	case nil:
+		return "", "", bounds, nil
+	case []byte:
+		return parseRange(string(src))
+	case string:
+		return parseRange(src)
+	default:
+		return "", "", bounds, fmt.Errorf("pgdialect: can't scan %T into a range", src)
+	}
+}
+
+func parseRange(s string) (lower, upper string, bounds RangeBounds, err error) {
+	if strings.EqualFold(s, "empty") {
+		bounds.Empty = true
+		return "", "", bounds, nil
+	}
+	if len(s) < 3 {
+		return "", "", bounds, fmt.Errorf("pgdialect: invalid range: %q", s)
+	}
+
+	switch s[0] {
+	case '[':
+		bounds.LowerInclusive = true
+	case '(':
+	default:
+		return "", "", bounds, fmt.Errorf("pgdialect: invalid range: %q", s)
+	}
+	switch s[len(s)-1] {
+	case ']':
+		bounds.UpperInclusive = true
+	case ')':
+	default:
+		return "", "", bounds, fmt.Errorf("pgdialect: invalid range: %q", s)
+	}
+
+	s = s[1 : len(s)-1]
+
+	lower, s, err = parseRangeBound(s)
+	if err != nil {
+		return "", "", bounds, err
+	}
+	if s == "" || s[0] != ',' {
+		return "", "", bounds, fmt.Errorf("pgdialect: invalid range: missing comma")
+	}
+	upper, s, err = parseRangeBound(s[1:])
+	if err != nil {
+		return "", "", bounds, err
+	}
+	if s != "" {
+		return "", "", bounds, fmt.Errorf("pgdialect: invalid range: unexpected %q", s)
+	}
+
+	bounds.LowerUnbounded = lower == ""
+	bounds.UpperUnbounded = upper == ""
+	if bounds.LowerUnbounded {
+		bounds.LowerInclusive = false
+	}
+	if bounds.UpperUnbounded {
+		bounds.UpperInclusive = false
+	}
+
+	return lower, upper, bounds, nil
+}
+
+// parseRangeBound parses a possibly quoted bound and returns the rest of the string.
+func parseRangeBound(s string) (string, string, error) {
+	if s == "" || s[0] != '"' {
+		if i := strings.IndexByte(s, ','); i >= 0 {
+			return s[:i], s[i:], nil
+		}
+		return s, "", nil
+	}
+
+	var b []byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			i++
+			if i < len(s) {
+				b = append(b, s[i])
+			}
+		case '"':
+			if i+1 < len(s) && s[i+1] == '"' {
+				b = append(b, '"')
+				i++
+				continue
+			}
+			return string(b), s[i+1:], nil
+		default:
+			b = append(b, c)
+		}
+	}
+	return "", "", fmt.Errorf("pgdialect: invalid range: unterminated quote")
+}
+
+func parseRangeTime(layout, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(layout, s, time.UTC)
+}
@@ -0,0 +1,92 @@
+package pgdialect
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRangeParser(t *testing.T) {
+	tests := []struct {
+		s      string
+		lower  string
+		upper  string
+		bounds RangeBounds
+	}{
+		{`empty`, "", "", RangeBounds{Empty: true}},
+		{`[1,5)`, "1", "5", RangeBounds{LowerInclusive: true}},
+		{`(1,5]`, "1", "5", RangeBounds{UpperInclusive: true}},
+		{`[1,)`, "1", "", RangeBounds{LowerInclusive: true, UpperUnbounded: true}},
+		{`(,5)`, "", "5", RangeBounds{LowerUnbounded: true}},
+		{
+			`["2021-01-01 10:00:00","2021-01-02 10:00:00")`,
+			"2021-01-01 10:00:00", "2021-01-02 10:00:00",
+			RangeBounds{LowerInclusive: true},
+		},
+		{`["a\"b","c""d")`, `a"b`, `c"d`, RangeBounds{LowerInclusive: true}},
+	}
+
+	for _, test := range tests {
+		lower, upper, bounds, err := parseRange(test.s)
+		if err != nil {
+			t.Fatalf("%q: %s", test.s, err)
+		}
+		if lower != test.lower || upper != test.upper || bounds != test.bounds {
+			t.Fatalf("%q: got %q, %q, %+v", test.s, lower, upper, bounds)
+		}
+	}
+
+	for _, s := range []string{"", "[1,5", "1,5)", "[15)", `["1,5)`} {
+		if _, _, _, err := parseRange(s); err == nil {
+			t.Fatalf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestRangeValueScan(t *testing.T) {
+	r := NewInt4Range(1, 5)
+	v, err := r.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "[1,5)" {
+		t.Fatalf("got %q", v)
+	}
+
+	var r2 Int4Range
+	if err := r2.Scan([]byte(v.(string))); err != nil {
+		t.Fatal(err)
+	}
+	if r2 != r {
+		t.Fatalf("got %+v, wanted %+v", r2, r)
+	}
+
+	lower := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	ts := NewTsRange(lower, lower.Add(time.Hour))
+	v, err = ts.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != `["2021-01-01 10:00:00","2021-01-01 11:00:00")` {
+		t.Fatalf("got %q", v)
+	}
+
+	var ts2 TsRange
+	if err := ts2.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !ts2.Lower.Equal(ts.Lower) || !ts2.Upper.Equal(ts.Upper) || ts2.RangeBounds != ts.RangeBounds {
+		t.Fatalf("got %+v, wanted %+v", ts2, ts)
+	}
+
+	var dr DateRange
+	if err := dr.Scan("[2021-01-01,)"); err != nil {
+		t.Fatal(err)
+	}
+	v, err = dr.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "[2021-01-01,)" {
+		t.Fatalf("got %q", v)
+	}
+}
@@ -52,6 +52,10 @@ func fieldSQLType(field *schema.Field) string {
 		return field.UserSQLType
 	}
 
+	if v, ok := field.Tag.Options["pgenum"]; ok {
+		return v
+	}
+
 	if v, ok := field.Tag.Options["composite"]; ok {
 		return v
 	}
@@ -60,6 +64,12 @@ func fieldSQLType(field *schema.Field) string {
 		return "hstore"
 	}
 
+	if _, ok := field.Tag.Options["range"]; ok {
+		if sqlType := rangeSQLType(field.IndirectType); sqlType != "" {
+			return sqlType
+		}
+	}
+
 	if _, ok := field.Tag.Options["array"]; ok {
 		switch field.IndirectType.Kind() {
 		case reflect.Slice, reflect.Array:
@@ -79,6 +89,8 @@ func sqlType(typ reflect.Type) string {
 		return pgTypeCidr
 	case jsonRawMessageType:
 		return pgTypeJSONB
+	case int4RangeType, tsRangeType, dateRangeType:
+		return rangeSQLType(typ)
 	}
 
 	sqlType := schema.DiscoverSQLType(typ)
@@ -22,7 +22,12 @@ type Dialect struct {
 func New() *Dialect {
 	d := new(Dialect)
 	d.tables = schema.NewTables(d)
-	d.features = feature.Returning | feature.InsertTableAlias | feature.DeleteTableAlias
+	d.features = feature.Returning |
+		feature.InsertTableAlias |
+		feature.DeleteTableAlias |
+		feature.WithRecursive |
+		feature.CTE |
+		feature.Window
 	return d
 }
 
@@ -36,6 +41,10 @@ func (d *Dialect) Features() feature.Feature {
 	return d.features
 }
 
+func (d *Dialect) SupportsFeature(f feature.Feature) bool {
+	return d.features.Has(f)
+}
+
 func (d *Dialect) Tables() *schema.Tables {
 	return d.tables
 }
@@ -6,9 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net"
-	"net/url"
 	"os"
-	"strings"
+	"strconv"
 	"time"
 )
 
@@ -39,6 +38,15 @@ type Config struct {
 	// Timeout for socket writes. If reached, commands will fail
 	// with a timeout instead of blocking.
 	WriteTimeout time.Duration
+
+	// TraceHook receives protocol-level events, see WithTraceHook.
+	TraceHook TraceHook
+
+	// Major protocol version sent in the startup message.
+	// Default is 3.
+	ProtocolVersion int
+	// Additional parameters sent in the startup message, e.g. options.
+	StartupParams map[string]string
 }
 
 func newDefaultConfig() *Config {
@@ -115,6 +123,35 @@ func WithApplicationName(appName string) DriverOption {
 	}
 }
 
+// WithProtocolVersion sets the major protocol version that is requested in
+// the startup message. The driver only implements protocol version 3 messages,
+// so other versions are only useful with proxies that translate the protocol.
+func WithProtocolVersion(version int) DriverOption {
+	if version <= 0 || version > 0xffff {
+		panic(fmt.Errorf("pgdriver: invalid protocol version: %d", version))
+	}
+	return func(d *Connector) {
+		d.cfg.ProtocolVersion = version
+	}
+}
+
+// WithStartupParam adds a parameter to the startup message, for example,
+// WithStartupParam("options", "-c search_path=myschema").
+func WithStartupParam(key, value string) DriverOption {
+	switch key {
+	case "":
+		panic("pgdriver: startup param key is empty")
+	case "user", "database":
+		panic(fmt.Errorf("pgdriver: use WithUser or WithDatabase to set %q", key))
+	}
+	return func(d *Connector) {
+		if d.cfg.StartupParams == nil {
+			d.cfg.StartupParams = make(map[string]string)
+		}
+		d.cfg.StartupParams[key] = value
+	}
+}
+
 func WithTimeout(timeout time.Duration) DriverOption {
 	return func(d *Connector) {
 		d.cfg.DialTimeout = timeout
@@ -154,62 +191,72 @@ func WithDSN(dsn string) DriverOption {
 }
 
 func parseDSN(dsn string) ([]DriverOption, error) {
-	u, err := url.Parse(dsn)
+	opts, err := ParseURL(dsn)
 	if err != nil {
 		return nil, err
 	}
+	return opts.driverOptions()
+}
 
-	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
-		return nil, errors.New("pgdriver: invalid scheme: " + u.Scheme)
+func sslModeOption(sslMode string) (DriverOption, error) {
+	switch sslMode {
+	case "verify-ca", "verify-full":
+		return WithTLSConfig(new(tls.Config)), nil
+	case "allow", "prefer", "require":
+		return WithTLSConfig(&tls.Config{InsecureSkipVerify: true}), nil
+	case "disable":
+		return WithTLSConfig(nil), nil
+	default:
+		return nil, fmt.Errorf("pgdriver: sslmode '%s' is not supported", sslMode)
 	}
+}
 
-	query, err := url.ParseQuery(u.RawQuery)
-	if err != nil {
-		return nil, err
+// FromEnv configures the connector using libpq environment variables:
+// PGHOST, PGPORT, PGDATABASE, PGUSER, PGPASSWORD, PGSSLMODE, and PGCONNECT_TIMEOUT.
+// Variables that are not set are ignored.
+func FromEnv() DriverOption {
+	return func(d *Connector) {
+		opts, err := parseEnv()
+		if err != nil {
+			panic(err)
+		}
+		for _, opt := range opts {
+			opt(d)
+		}
 	}
+}
 
+func parseEnv() ([]DriverOption, error) {
 	var opts []DriverOption
 
-	if u.Host != "" {
-		addr := u.Host
-		if !strings.Contains(addr, ":") {
-			addr += ":5432"
-		}
-		opts = append(opts, WithAddr(addr))
+	host, port := os.Getenv("PGHOST"), os.Getenv("PGPORT")
+	if host != "" || port != "" {
+		opts = append(opts, WithAddr(net.JoinHostPort(
+			env("PGHOST", "localhost"), env("PGPORT", "5432"))))
 	}
-	if u.User != nil {
-		opts = append(opts, WithUser(u.User.Username()))
-		if password, ok := u.User.Password(); ok {
-			opts = append(opts, WithPassword(password))
-		}
+	if user := os.Getenv("PGUSER"); user != "" {
+		opts = append(opts, WithUser(user))
 	}
-	if len(u.Path) > 1 {
-		opts = append(opts, WithDatabase(u.Path[1:]))
+	if password := os.Getenv("PGPASSWORD"); password != "" {
+		opts = append(opts, WithPassword(password))
 	}
-
-	if appName := query.Get("application_name"); appName != "" {
-		opts = append(opts, WithApplicationName(appName))
+	if database := os.Getenv("PGDATABASE"); database != "" {
+		opts = append(opts, WithDatabase(database))
 	}
-	delete(query, "application_name")
-
-	if sslMode := query.Get("sslmode"); sslMode != "" {
-		switch sslMode {
-		case "verify-ca", "verify-full":
-			opts = append(opts, WithTLSConfig(new(tls.Config)))
-		case "allow", "prefer", "require":
-			opts = append(opts, WithTLSConfig(&tls.Config{InsecureSkipVerify: true}))
-		case "disable":
-			// no TLS config
-		default:
-			return nil, fmt.Errorf("pgdriver: sslmode '%s' is not supported", sslMode)
+	if sslMode := os.Getenv("PGSSLMODE"); sslMode != "" {
+		opt, err := sslModeOption(sslMode)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		opts = append(opts, WithTLSConfig(&tls.Config{InsecureSkipVerify: true}))
+		opts = append(opts, opt)
 	}
-	delete(query, "sslmode")
-
-	for key := range query {
-		return nil, fmt.Errorf("pgdriver: unsupported option=%q", key)
+	if s := os.Getenv("PGCONNECT_TIMEOUT"); s != "" {
+		sec, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("pgdriver: invalid PGCONNECT_TIMEOUT=%q", s)
+		}
+		// Zero means wait indefinitely, just like in libpq.
+		opts = append(opts, WithDialTimeout(time.Duration(sec)*time.Second))
 	}
 
 	return opts, nil
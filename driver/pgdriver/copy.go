@@ -0,0 +1,204 @@
+package pgdriver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+var errCopyFromStdin = errors.New(
+	"pgdriver: CopyFrom executes server-side COPY and does not support FROM STDIN")
+
+// CopyFrom executes a server-side COPY query that reads a file on the PostgreSQL
+// server, for example, `COPY table FROM '/path/to/file' WITH CSV`,
+// and returns the number of copied rows.
+func CopyFrom(ctx context.Context, conn bun.IConn, query string) (int64, error) {
+	if isCopyFromStdin(query) {
+		return 0, errCopyFromStdin
+	}
+
+	res, err := conn.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// isCopyFromStdin reports whether the query has a FROM STDIN clause.
+// Words in quoted strings and identifiers are ignored and comments
+// are treated as whitespace.
+func isCopyFromStdin(query string) bool {
+	var prev string
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == '\'' || c == '"':
+			j := strings.IndexByte(query[i+1:], c)
+			if j == -1 {
+				return false
+			}
+			i += j + 2
+			prev = ""
+		case isWordChar(c):
+			j := i + 1
+			for j < len(query) && isWordChar(query[j]) {
+				j++
+			}
+			word := query[i:j]
+			if strings.EqualFold(prev, "FROM") && strings.EqualFold(word, "STDIN") {
+				return true
+			}
+			prev = word
+			i = j
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			j := strings.IndexByte(query[i:], '\n')
+			if j == -1 {
+				return false
+			}
+			i += j
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			j := strings.Index(query[i+2:], "*/")
+			if j == -1 {
+				return false
+			}
+			i += j + 4
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		default:
+			prev = ""
+			i++
+		}
+	}
+	return false
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// CopyTo executes `COPY (query) TO STDOUT WITH CSV HEADER`, writes the output
+// to w, and returns the number of copied rows. The query can also be a complete
+// COPY statement, for example, `COPY table TO STDOUT`.
+//
+// The conn must be a *bun.DB, bun.Conn, *sql.DB, or *sql.Conn, because
+// database/sql does not provide access to the driver connection of a transaction.
+func CopyTo(ctx context.Context, conn bun.IConn, w io.Writer, query string) (int64, error) {
+	if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(query)), "COPY") {
+		query = "COPY (" + query + ") TO STDOUT WITH CSV HEADER"
+	}
+
+	var n int64
+	err := withDriverConn(ctx, conn, func(cn *Conn) error {
+		if cn.isClosed() {
+			return sql.ErrConnDone
+		}
+
+		var err error
+		n, err = copyTo(ctx, cn, w, query)
+		return err
+	})
+	return n, err
+}
+
+func copyTo(ctx context.Context, cn *Conn, w io.Writer, query string) (int64, error) {
+	if err := writeQuery(ctx, cn, query); err != nil {
+		return 0, cn.checkBadConn(err)
+	}
+
+	rd := cn.reader(ctx, -1)
+
+	var n int64
+	var firstErr error
+	for {
+		c, msgLen, err := readMessageType(rd)
+		if err != nil {
+			return 0, cn.checkBadConn(err)
+		}
+
+		switch c {
+		case copyDataMsg:
+			b, err := rd.ReadTemp(msgLen)
+			if err != nil {
+				return 0, cn.checkBadConn(err)
+			}
+			// Keep reading after a write error to leave the connection usable.
+			if firstErr == nil {
+				if _, err := w.Write(b); err != nil {
+					firstErr = err
+				}
+			}
+		case commandCompleteMsg:
+			tmp, err := rd.ReadTemp(msgLen)
+			if err != nil {
+				return 0, cn.checkBadConn(err)
+			}
+			res, err := parseResult(tmp)
+			if err != nil {
+				firstErr = err
+			} else {
+				n = int64(res)
+			}
+		case errorResponseMsg:
+			e, err := readError(rd)
+			if err != nil {
+				return 0, cn.checkBadConn(err)
+			}
+			if firstErr == nil {
+				firstErr = e
+			}
+		case emptyQueryResponseMsg:
+			if firstErr == nil {
+				firstErr = errEmptyQuery
+			}
+		case copyOutResponseMsg,
+			copyDoneMsg,
+			noticeResponseMsg,
+			parameterStatusMsg:
+			if err := rd.Discard(msgLen); err != nil {
+				return 0, cn.checkBadConn(err)
+			}
+		case readyForQueryMsg:
+			if err := rd.Discard(msgLen); err != nil {
+				return 0, cn.checkBadConn(err)
+			}
+			return n, firstErr
+		default:
+			return 0, fmt.Errorf("pgdriver: CopyTo: unexpected message %q", c)
+		}
+	}
+}
+
+func withDriverConn(ctx context.Context, conn bun.IConn, fn func(cn *Conn) error) error {
+	switch conn := conn.(type) {
+	case *sql.Conn:
+		return conn.Raw(func(driverConn interface{}) error {
+			cn, ok := driverConn.(*Conn)
+			if !ok {
+				return fmt.Errorf("pgdriver: got %T, wanted *pgdriver.Conn", driverConn)
+			}
+			return fn(cn)
+		})
+	case bun.Conn:
+		return withDriverConn(ctx, conn.Conn, fn)
+	case *bun.DB:
+		sqlConn, err := conn.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer sqlConn.Close()
+		return withDriverConn(ctx, sqlConn.Conn, fn)
+	case *sql.DB:
+		sqlConn, err := conn.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer sqlConn.Close()
+		return withDriverConn(ctx, sqlConn, fn)
+	default:
+		return fmt.Errorf("pgdriver: CopyTo does not support %T", conn)
+	}
+}
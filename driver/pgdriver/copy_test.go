@@ -0,0 +1,32 @@
+package pgdriver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCopyFromStdin(t *testing.T) {
+	tests := []struct {
+		query  string
+		wanted bool
+	}{
+		{"COPY t FROM STDIN", true},
+		{"copy t (a, b) from stdin with csv", true},
+		{"COPY t FROM\n\tSTDIN", true},
+		{"COPY t FROM '/data/stdin.csv'", false},
+		{"COPY stdin FROM '/data/file.csv'", false},
+		{`COPY "from stdin" FROM '/data/file.csv'`, false},
+		{"COPY stdin_data FROM '/data/file.csv'", false},
+		{"COPY t FROM PROGRAM 'cat /dev/stdin'", false},
+		{"COPY t TO STDOUT", false},
+		{"COPY t FROM /* x */ STDIN", true},
+		{"COPY t FROM -- x\nSTDIN", true},
+		{"COPY t FROM/**/STDIN", true},
+		{"COPY t FROM '/data/file.csv' -- FROM STDIN", false},
+		{"COPY t FROM '/data/file.csv' /* FROM STDIN */", false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.wanted, isCopyFromStdin(test.query), test.query)
+	}
+}
@@ -190,11 +190,14 @@ func (cn *Conn) Prepare(query string) (driver.Stmt, error) {
 	name := fmt.Sprintf("pgdriver-%d", cn.stmtCount)
 	cn.stmtCount++
 
-	if err := writeParseDescribeSync(ctx, cn, name, query); err != nil {
-		return nil, err
+	start := time.Now()
+	rowDesc, err := cn.parse(ctx, name, query)
+	if hook := cn.traceHook(); hook != nil {
+		event := newTraceEvent(start, []byte{parseMsg, describeMsg, syncMsg}, err)
+		event.Query = query
+		event.StmtName = name
+		hook.TraceParse(ctx, event)
 	}
-
-	rowDesc, err := readParseDescribeSync(ctx, cn)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +205,13 @@ func (cn *Conn) Prepare(query string) (driver.Stmt, error) {
 	return newStmt(cn, name, rowDesc), nil
 }
 
+func (cn *Conn) parse(ctx context.Context, name, query string) (*rowDescription, error) {
+	if err := writeParseDescribeSync(ctx, cn, name, query); err != nil {
+		return nil, err
+	}
+	return readParseDescribeSync(ctx, cn)
+}
+
 func (cn *Conn) Close() error {
 	if !atomic.CompareAndSwapInt32(&cn.closed, 0, 1) {
 		return nil
@@ -257,12 +267,28 @@ func (cn *Conn) exec(
 	if err != nil {
 		return nil, err
 	}
+
+	start := time.Now()
+	res, err := cn.simpleExec(ctx, query)
+	cn.traceQuery(ctx, start, query, err)
+	return res, err
+}
+
+func (cn *Conn) simpleExec(ctx context.Context, query string) (driver.Result, error) {
 	if err := writeQuery(ctx, cn, query); err != nil {
 		return nil, err
 	}
 	return readQuery(ctx, cn)
 }
 
+func (cn *Conn) traceQuery(ctx context.Context, start time.Time, query string, err error) {
+	if hook := cn.traceHook(); hook != nil {
+		event := newTraceEvent(start, []byte{queryMsg}, err)
+		event.Query = query
+		hook.TraceQuery(ctx, event)
+	}
+}
+
 var _ driver.QueryerContext = (*Conn)(nil)
 
 func (cn *Conn) QueryContext(
@@ -285,6 +311,14 @@ func (cn *Conn) query(
 	if err != nil {
 		return nil, err
 	}
+
+	start := time.Now()
+	rows, err := cn.simpleQuery(ctx, query)
+	cn.traceQuery(ctx, start, query, err)
+	return rows, err
+}
+
+func (cn *Conn) simpleQuery(ctx context.Context, query string) (*rows, error) {
 	if err := writeQuery(ctx, cn, query); err != nil {
 		return nil, err
 	}
@@ -572,10 +606,14 @@ func (stmt *stmt) Exec(args []driver.Value) (driver.Result, error) {
 }
 
 func (stmt *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
-	if err := writeBindExecute(ctx, stmt.cn, stmt.name, args); err != nil {
+	if err := stmt.bind(ctx, args); err != nil {
 		return nil, err
 	}
-	return readExtQuery(ctx, stmt.cn)
+
+	start := time.Now()
+	res, err := readExtQuery(ctx, stmt.cn)
+	stmt.traceExecute(ctx, start, args, err)
+	return res, err
 }
 
 func (stmt *stmt) Query(args []driver.Value) (driver.Rows, error) {
@@ -583,10 +621,37 @@ func (stmt *stmt) Query(args []driver.Value) (driver.Rows, error) {
 }
 
 func (stmt *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
-	if err := writeBindExecute(ctx, stmt.cn, stmt.name, args); err != nil {
+	if err := stmt.bind(ctx, args); err != nil {
 		return nil, err
 	}
-	return readExtQueryData(ctx, stmt.cn, stmt.rowDesc)
+
+	start := time.Now()
+	rows, err := readExtQueryData(ctx, stmt.cn, stmt.rowDesc)
+	stmt.traceExecute(ctx, start, args, err)
+	return rows, err
+}
+
+func (stmt *stmt) bind(ctx context.Context, args []driver.NamedValue) error {
+	start := time.Now()
+	err := writeBindExecute(ctx, stmt.cn, stmt.name, args)
+	if hook := stmt.cn.traceHook(); hook != nil {
+		event := newTraceEvent(start, []byte{bindMsg, executeMsg, syncMsg}, err)
+		event.StmtName = stmt.name
+		event.NumArgs = len(args)
+		hook.TraceBind(ctx, event)
+	}
+	return err
+}
+
+func (stmt *stmt) traceExecute(
+	ctx context.Context, start time.Time, args []driver.NamedValue, err error,
+) {
+	if hook := stmt.cn.traceHook(); hook != nil {
+		event := newTraceEvent(start, nil, err)
+		event.StmtName = stmt.name
+		event.NumArgs = len(args)
+		hook.TraceExecute(ctx, event)
+	}
 }
 
 //------------------------------------------------------------------------------
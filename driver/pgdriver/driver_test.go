@@ -1,6 +1,7 @@
 package pgdriver_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"os"
@@ -203,6 +204,109 @@ func TestFloat64(t *testing.T) {
 	require.Equal(t, 1.1, f)
 }
 
+func TestCopyFrom(t *testing.T) {
+	ctx := context.Background()
+	db := sqlDB()
+
+	_, err := db.Exec("DROP TABLE IF EXISTS copy_from_test")
+	require.NoError(t, err)
+
+	_, err = db.Exec("CREATE TABLE copy_from_test (num int)")
+	require.NoError(t, err)
+
+	n, err := pgdriver.CopyFrom(ctx, db,
+		`COPY copy_from_test FROM PROGRAM 'printf "1\n2\n3\n"' WITH CSV`)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	_, err = pgdriver.CopyFrom(ctx, db, "COPY copy_from_test FROM STDIN")
+	require.Error(t, err)
+
+	n, err = pgdriver.CopyFrom(ctx, db,
+		`COPY copy_from_test FROM PROGRAM 'printf "4\n" # stdin' WITH CSV`)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+}
+
+func TestCopyTo(t *testing.T) {
+	ctx := context.Background()
+	db := sqlDB()
+
+	var buf bytes.Buffer
+	n, err := pgdriver.CopyTo(ctx, db, &buf, "SELECT n, 'foo' AS s FROM generate_series(1, 3) AS n")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+	require.Equal(t, "n,s\n1,foo\n2,foo\n3,foo\n", buf.String())
+
+	buf.Reset()
+	n, err = pgdriver.CopyTo(ctx, db, &buf, "COPY (SELECT 1) TO STDOUT")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	require.Equal(t, "1\n", buf.String())
+
+	_, err = pgdriver.CopyTo(ctx, db, &buf, "SELECT * FROM copy_to_missing_table")
+	require.Error(t, err)
+
+	var num int
+	err = db.QueryRow("SELECT 1").Scan(&num)
+	require.NoError(t, err)
+	require.Equal(t, 1, num)
+}
+
+type traceHook struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (h *traceHook) add(phase string, event *pgdriver.TraceEvent) {
+	h.mu.Lock()
+	h.events = append(h.events, phase+":"+string(event.Messages))
+	h.mu.Unlock()
+}
+
+func (h *traceHook) TraceParse(ctx context.Context, event *pgdriver.TraceEvent) {
+	h.add("parse", event)
+}
+
+func (h *traceHook) TraceBind(ctx context.Context, event *pgdriver.TraceEvent) {
+	h.add("bind", event)
+}
+
+func (h *traceHook) TraceExecute(ctx context.Context, event *pgdriver.TraceEvent) {
+	h.add("execute", event)
+}
+
+func (h *traceHook) TraceQuery(ctx context.Context, event *pgdriver.TraceEvent) {
+	h.add("query", event)
+}
+
+func TestTraceHook(t *testing.T) {
+	hook := new(traceHook)
+	db := sql.OpenDB(pgdriver.NewConnector(
+		pgdriver.WithDSN(dsn()),
+		pgdriver.WithTraceHook(hook),
+	))
+	defer db.Close()
+
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	hook.events = nil
+
+	_, err = conn.ExecContext(context.Background(), "SELECT 1")
+	require.NoError(t, err)
+
+	stmt, err := conn.PrepareContext(context.Background(), "SELECT $1")
+	require.NoError(t, err)
+	defer stmt.Close()
+
+	_, err = stmt.Exec("hello")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"query:Q", "parse:PDS", "bind:BES", "execute:"}, hook.events)
+}
+
 func sqlDB() *sql.DB {
 	db, err := sql.Open("pg", dsn())
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -194,16 +195,36 @@ func writeStartup(ctx context.Context, cn *Conn) error {
 	wb := getWriteBuffer()
 	defer putWriteBuffer(wb)
 
+	cfg := cn.driver.cfg
+
+	version := cfg.ProtocolVersion
+	if version == 0 {
+		version = 3
+	}
+
 	wb.StartMessage(0)
-	wb.WriteInt32(196608)
+	wb.WriteInt32(int32(version << 16))
 	wb.WriteString("user")
-	wb.WriteString(cn.driver.cfg.User)
+	wb.WriteString(cfg.User)
 	wb.WriteString("database")
-	wb.WriteString(cn.driver.cfg.Database)
-	if cn.driver.cfg.AppName != "" {
-		wb.WriteString("application_name")
-		wb.WriteString(cn.driver.cfg.AppName)
+	wb.WriteString(cfg.Database)
+	if cfg.AppName != "" {
+		if _, ok := cfg.StartupParams["application_name"]; !ok {
+			wb.WriteString("application_name")
+			wb.WriteString(cfg.AppName)
+		}
 	}
+
+	keys := make([]string, 0, len(cfg.StartupParams))
+	for key := range cfg.StartupParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		wb.WriteString(key)
+		wb.WriteString(cfg.StartupParams[key])
+	}
+
 	wb.WriteString("")
 	wb.FinishMessage()
 
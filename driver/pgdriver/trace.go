@@ -0,0 +1,55 @@
+package pgdriver
+
+import (
+	"context"
+	"time"
+)
+
+// TraceHook receives PostgreSQL wire protocol events. Unlike bun.QueryHook,
+// it is called by the driver for each protocol phase of the query execution.
+type TraceHook interface {
+	// TraceParse is called after a statement is prepared using Parse, Describe,
+	// and Sync messages.
+	TraceParse(ctx context.Context, event *TraceEvent)
+	// TraceBind is called after arguments are sent to the server using Bind,
+	// Execute, and Sync messages.
+	TraceBind(ctx context.Context, event *TraceEvent)
+	// TraceExecute is called after the result of a prepared statement is received.
+	TraceExecute(ctx context.Context, event *TraceEvent)
+	// TraceQuery is called after the result of a simple Query message is received.
+	TraceQuery(ctx context.Context, event *TraceEvent)
+}
+
+type TraceEvent struct {
+	StartTime time.Time
+	Duration  time.Duration
+
+	// Messages contains types of the protocol messages sent to the server,
+	// for example, 'P', 'D', 'S' for Parse, Describe, and Sync.
+	Messages []byte
+
+	Query    string
+	StmtName string
+	NumArgs  int
+
+	Err error
+}
+
+func WithTraceHook(hook TraceHook) DriverOption {
+	return func(d *Connector) {
+		d.cfg.TraceHook = hook
+	}
+}
+
+func (cn *Conn) traceHook() TraceHook {
+	return cn.driver.cfg.TraceHook
+}
+
+func newTraceEvent(start time.Time, messages []byte, err error) *TraceEvent {
+	return &TraceEvent{
+		StartTime: start,
+		Duration:  time.Since(start),
+		Messages:  messages,
+		Err:       err,
+	}
+}
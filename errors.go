@@ -0,0 +1,95 @@
+package bun
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// ErrDialectUnsupported is returned by queries that the current dialect does
+// not support, for example, CREATE SCHEMA on SQLite.
+var ErrDialectUnsupported = errors.New("bun: query is not supported by the current dialect")
+
+// QueryError is returned when the database fails to execute a query built with bun.
+// It has the same message as the driver error, which is available using
+// errors.As or errors.Is, for example:
+//
+//	var pgErr pgdriver.Error
+//	if errors.As(err, &pgErr) && pgErr.IntegrityViolation() {
+//		// handle the error
+//	}
+type QueryError struct {
+	err       error
+	query     string
+	args      []interface{}
+	operation string
+}
+
+var _ error = (*QueryError)(nil)
+
+func newQueryError(err error, queryApp schema.QueryAppender, query string) error {
+	if err == nil {
+		return nil
+	}
+	return &QueryError{
+		err:       err,
+		query:     query,
+		operation: queryOperation(queryApp, query),
+	}
+}
+
+// newRawQueryError wraps the error of a raw query executed with DB, Conn, or Tx.
+func newRawQueryError(err error, query string, args []interface{}) error {
+	return &QueryError{
+		err:       err,
+		query:     query,
+		args:      args,
+		operation: queryOperation(nil, query),
+	}
+}
+
+func (e *QueryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.err
+}
+
+// Query returns the formatted query.
+func (e *QueryError) Query() string {
+	return e.query
+}
+
+// Args returns the args of a raw query executed with DB.ExecContext,
+// DB.QueryContext, or the same methods of Conn and Tx. Query returns
+// the query with the args already formatted into it. Queries built with bun
+// format the args while the query is built, so Args returns nil for them.
+func (e *QueryError) Args() []interface{} {
+	return e.args
+}
+
+// Operation returns the type of the query, for example, SELECT or INSERT.
+func (e *QueryError) Operation() string {
+	return e.operation
+}
+
+func queryOperation(queryApp schema.QueryAppender, query string) string {
+	switch queryApp.(type) {
+	case *SelectQuery, countQuery, selectExistsQuery:
+		return "SELECT"
+	case *InsertQuery:
+		return "INSERT"
+	case *UpdateQuery:
+		return "UPDATE"
+	case *DeleteQuery:
+		return "DELETE"
+	}
+
+	query = strings.TrimSpace(query)
+	if i := strings.IndexAny(query, " \t\n("); i >= 0 {
+		query = query[:i]
+	}
+	return strings.ToUpper(query)
+}
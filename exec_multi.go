@@ -0,0 +1,131 @@
+package bun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/uptrace/bun/dialect"
+)
+
+// ExecMulti executes the semicolon-separated SQL statements one by one,
+// for example, a DDL script. Semicolons in quoted strings, identifiers, comments,
+// PostgreSQL dollar-quoted strings, and MySQL strings with backslash escapes
+// and # comments do not separate statements.
+// It stops at the first error and returns it with the index of the statement.
+func (db *DB) ExecMulti(ctx context.Context, query string) error {
+	stmts := splitStatements(query, db.dialect.Name())
+	for i, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("bun: statement %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// splitStatements splits the query on semicolons and omits empty statements.
+func splitStatements(query string, name dialect.Name) []string {
+	dollarQuotes := name == dialect.PG
+	backslashEscapes := name == dialect.MySQL5 || name == dialect.MySQL8
+	hashComments := backslashEscapes
+
+	var stmts []string
+
+	start := 0
+	add := func(end int) {
+		if stmt := strings.TrimSpace(query[start:end]); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		start = end + 1
+	}
+
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; c {
+		case ';':
+			add(i)
+		case '\'', '"':
+			i = skipQuote(query, i, c, backslashEscapes)
+		case '`':
+			i = skipQuote(query, i, c, false)
+		case '-':
+			if i+1 < len(query) && query[i+1] == '-' {
+				i = skipLine(query, i)
+			}
+		case '#':
+			if hashComments {
+				i = skipLine(query, i)
+			}
+		case '/':
+			if i+1 < len(query) && query[i+1] == '*' {
+				if j := strings.Index(query[i+2:], "*/"); j >= 0 {
+					i += j + 3
+				} else {
+					i = len(query)
+				}
+			}
+		case '$':
+			if !dollarQuotes {
+				continue
+			}
+			tag, ok := dollarQuoteTag(query[i:])
+			if !ok {
+				continue
+			}
+			if j := strings.Index(query[i+len(tag):], tag); j >= 0 {
+				i += len(tag) + j + len(tag) - 1
+			} else {
+				i = len(query)
+			}
+		}
+	}
+	if start < len(query) {
+		add(len(query))
+	}
+
+	return stmts
+}
+
+// skipLine returns the index of the newline that ends the line comment.
+func skipLine(s string, i int) int {
+	if j := strings.IndexByte(s[i:], '\n'); j >= 0 {
+		return i + j
+	}
+	return len(s)
+}
+
+// skipQuote returns the index of the closing quote.
+// Doubled quotes are treated as escaped quotes and so are quotes
+// preceded by a backslash if backslashEscapes is true.
+func skipQuote(s string, i int, quote byte, backslashEscapes bool) int {
+	for i++; i < len(s); i++ {
+		if backslashEscapes && s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] != quote {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == quote {
+			i++
+			continue
+		}
+		return i
+	}
+	return len(s)
+}
+
+// dollarQuoteTag returns the opening tag, e.g. $$ or $body$, if s starts with one.
+func dollarQuoteTag(s string) (string, bool) {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '$':
+			return s[:i+1], true
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 1:
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
@@ -21,8 +21,30 @@ func WithVerbose() ConfigOption {
 	}
 }
 
+// WithRedactedColumns replaces values bound to the columns with [REDACTED]
+// in the logged queries, e.g. "password" = '[REDACTED]'.
+func WithRedactedColumns(columns ...string) ConfigOption {
+	return func(h *QueryHook) {
+		if h.redactor.columns == nil {
+			h.redactor.columns = make(map[string]struct{}, len(columns))
+		}
+		for _, column := range columns {
+			h.redactor.columns[strings.ToLower(column)] = struct{}{}
+		}
+	}
+}
+
+// WithRedactAll replaces all string and number literals with [REDACTED]
+// in the logged queries.
+func WithRedactAll() ConfigOption {
+	return func(h *QueryHook) {
+		h.redactor.all = true
+	}
+}
+
 type QueryHook struct {
-	verbose bool
+	verbose  bool
+	redactor redactor
 }
 
 var _ bun.QueryHook = (*QueryHook)(nil)
@@ -52,12 +74,17 @@ func (h *QueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
 	now := time.Now()
 	dur := now.Sub(event.StartTime)
 
+	query := event.Query
+	if h.redactor.enabled() {
+		query = h.redactor.redact(query)
+	}
+
 	args := []interface{}{
 		"[bun]",
 		now.Format(" 15:04:05.000 "),
 		formatOperation(event),
 		fmt.Sprintf(" %10s ", dur.Round(time.Microsecond)),
-		event.Query,
+		query,
 	}
 
 	if event.Err != nil {
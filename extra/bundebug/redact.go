@@ -0,0 +1,285 @@
+package bundebug
+
+import (
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenPunct
+)
+
+type token struct {
+	kind       tokenKind
+	start, end int
+}
+
+// redactor replaces literal values in formatted queries.
+type redactor struct {
+	all     bool
+	columns map[string]struct{}
+}
+
+func (r *redactor) enabled() bool {
+	return r.all || len(r.columns) > 0
+}
+
+func (r *redactor) redact(query string) string {
+	toks := tokenize(query)
+
+	var b strings.Builder
+	b.Grow(len(query))
+
+	last := 0
+	for i, tok := range toks {
+		if tok.kind != tokenString && tok.kind != tokenNumber {
+			continue
+		}
+		if !r.all && !r.isRedactedColumn(r.literalColumn(query, toks, i)) {
+			continue
+		}
+		b.WriteString(query[last:tok.start])
+		b.WriteString(redacted)
+		last = tok.end
+	}
+	b.WriteString(query[last:])
+
+	return b.String()
+}
+
+func (r *redactor) isRedactedColumn(column string) bool {
+	if column == "" {
+		return false
+	}
+	_, ok := r.columns[strings.ToLower(column)]
+	return ok
+}
+
+// literalColumn returns the name of the column the literal at index i is bound to.
+// It recognizes comparisons like "col" = 'value' or "col" IN ('a', 'b')
+// and the VALUES lists of INSERT queries.
+func (r *redactor) literalColumn(query string, toks []token, i int) string {
+	if column := comparisonColumn(query, toks, i); column != "" {
+		return column
+	}
+	return insertColumn(query, toks, i)
+}
+
+func comparisonColumn(query string, toks []token, i int) string {
+	// Skip the opening parens of IN (...) lists.
+	depth := 0
+	for j := i - 1; j >= 0; j-- {
+		tok := toks[j]
+		text := query[tok.start:tok.end]
+
+		switch {
+		case tok.kind == tokenPunct && text == ",":
+			if depth == 0 {
+				// Only literals inside a parenthesized list can follow a comma.
+				depth = -1
+			}
+			continue
+		case tok.kind == tokenString || tok.kind == tokenNumber:
+			if depth == -1 {
+				continue
+			}
+			return ""
+		case tok.kind == tokenPunct && text == "(":
+			depth = 1
+			continue
+		}
+
+		if !isComparisonOp(tok, text) {
+			return ""
+		}
+		if j == 0 {
+			return ""
+		}
+		return identName(query, toks[j-1])
+	}
+	return ""
+}
+
+func isComparisonOp(tok token, text string) bool {
+	switch tok.kind {
+	case tokenPunct:
+		switch text {
+		case "=", "<>", "!=", "<", ">", "<=", ">=":
+			return true
+		}
+	case tokenWord:
+		switch strings.ToUpper(text) {
+		case "LIKE", "ILIKE", "IN":
+			return true
+		}
+	}
+	return false
+}
+
+func insertColumn(query string, toks []token, i int) string {
+	text := func(j int) string {
+		return query[toks[j].start:toks[j].end]
+	}
+
+	// Find the opening paren of the tuple that contains the literal.
+	pos := 0
+	j := i - 1
+	for depth := 0; j >= 0; j-- {
+		if toks[j].kind != tokenPunct {
+			continue
+		}
+		switch text(j) {
+		case ")", "]":
+			depth++
+			continue
+		case ",":
+			if depth == 0 {
+				pos++
+			}
+			continue
+		case "(", "[":
+			if depth > 0 {
+				depth--
+				continue
+			}
+		default:
+			continue
+		}
+		break
+	}
+	if j < 0 || text(j) != "(" {
+		return ""
+	}
+
+	// Skip preceding tuples until the VALUES keyword.
+	for j--; ; j-- {
+		if j < 0 {
+			return ""
+		}
+		if toks[j].kind == tokenWord && strings.EqualFold(text(j), "VALUES") {
+			break
+		}
+		if text(j) != "," {
+			return ""
+		}
+		j--
+		if j < 0 || text(j) != ")" {
+			return ""
+		}
+		for depth := 0; j >= 0; j-- {
+			if toks[j].kind != tokenPunct {
+				continue
+			}
+			if t := text(j); t == ")" || t == "]" {
+				depth++
+			} else if t == "(" || t == "[" {
+				depth--
+			}
+			if depth == 0 {
+				break
+			}
+		}
+	}
+
+	// The column list precedes VALUES: INSERT INTO "table" ("a", "b") VALUES ...
+	j--
+	if j < 0 || text(j) != ")" {
+		return ""
+	}
+	var columns []string
+	for j--; j >= 0; j-- {
+		t := text(j)
+		if t == "(" {
+			break
+		}
+		if t != "," {
+			columns = append(columns, identName(query, toks[j]))
+		}
+	}
+
+	// Columns were collected in reverse order.
+	if pos >= len(columns) {
+		return ""
+	}
+	return columns[len(columns)-1-pos]
+}
+
+func identName(query string, tok token) string {
+	switch tok.kind {
+	case tokenIdent:
+		return query[tok.start+1 : tok.end-1]
+	case tokenWord:
+		return query[tok.start:tok.end]
+	}
+	return ""
+}
+
+//------------------------------------------------------------------------------
+
+func tokenize(s string) []token {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		start := i
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '\'':
+			i = skipQuoted(s, i, '\'')
+			toks = append(toks, token{kind: tokenString, start: start, end: i})
+		case c == '"' || c == '`':
+			i = skipQuoted(s, i, c)
+			toks = append(toks, token{kind: tokenIdent, start: start, end: i})
+		case isDigit(c):
+			for i < len(s) && (isDigit(s[i]) || s[i] == '.' || s[i] == 'e' || s[i] == 'E') {
+				i++
+			}
+			toks = append(toks, token{kind: tokenNumber, start: start, end: i})
+		case isWordChar(c):
+			for i < len(s) && (isWordChar(s[i]) || isDigit(s[i])) {
+				i++
+			}
+			toks = append(toks, token{kind: tokenWord, start: start, end: i})
+		default:
+			i++
+			if i < len(s) && (c == '<' || c == '>' || c == '!') && (s[i] == '=' || s[i] == '>') {
+				i++
+			}
+			toks = append(toks, token{kind: tokenPunct, start: start, end: i})
+		}
+	}
+	return toks
+}
+
+// skipQuoted returns the index after the closing quote.
+// A doubled quote character is treated as an escaped quote.
+func skipQuoted(s string, i int, quote byte) int {
+	for i++; i < len(s); i++ {
+		if s[i] != quote {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(s)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
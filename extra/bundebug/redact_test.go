@@ -0,0 +1,48 @@
+package bundebug
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	r := &redactor{
+		columns: map[string]struct{}{"password": {}, "email": {}},
+	}
+
+	tests := []struct {
+		query  string
+		wanted string
+	}{
+		{
+			`SELECT * FROM "users" WHERE ("users"."email" = 'a@b.c') AND (id = 1)`,
+			`SELECT * FROM "users" WHERE ("users"."email" = [REDACTED]) AND (id = 1)`,
+		},
+		{
+			`SELECT * FROM users WHERE email IN ('a', 'it''s') AND name IN ('x')`,
+			`SELECT * FROM users WHERE email IN ([REDACTED], [REDACTED]) AND name IN ('x')`,
+		},
+		{
+			`INSERT INTO "users" ("id", "email", "password") VALUES (1, 'a', 'secret'), (2, lower('b'), 'x')`,
+			`INSERT INTO "users" ("id", "email", "password") VALUES (1, [REDACTED], [REDACTED]), (2, lower('b'), [REDACTED])`,
+		},
+		{
+			`INSERT INTO users (id, password) VALUES (DEFAULT, 'secret'::VARCHAR)`,
+			`INSERT INTO users (id, password) VALUES (DEFAULT, [REDACTED]::VARCHAR)`,
+		},
+		{
+			`UPDATE "users" SET "password" = 'secret', "name" = 'bob' WHERE id = 1`,
+			`UPDATE "users" SET "password" = [REDACTED], "name" = 'bob' WHERE id = 1`,
+		},
+	}
+
+	for _, test := range tests {
+		if got := r.redact(test.query); got != test.wanted {
+			t.Fatalf("\ngot:    %s\nwanted: %s", got, test.wanted)
+		}
+	}
+
+	all := &redactor{all: true}
+	got := all.redact(`SELECT 'a', 42 FROM "t" WHERE "x1" = 'it''s'`)
+	wanted := `SELECT [REDACTED], [REDACTED] FROM "t" WHERE "x1" = [REDACTED]`
+	if got != wanted {
+		t.Fatalf("got %s, wanted %s", got, wanted)
+	}
+}
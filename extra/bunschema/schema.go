@@ -0,0 +1,125 @@
+package bunschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/uptrace/bun/dialect/sqltype"
+	"github.com/uptrace/bun/schema"
+)
+
+const draft = "http://json-schema.org/draft-07/schema#"
+
+var tables = schema.DefaultNopDialect().Tables()
+
+// JSONSchema generates a JSON Schema document that describes the database-facing
+// fields of the models. Each model is added to the "definitions" section
+// under its Go type name and its properties are named after the table columns.
+func JSONSchema(models ...interface{}) ([]byte, error) {
+	defs := make(map[string]interface{}, len(models))
+	for _, model := range models {
+		table, err := modelTable(model)
+		if err != nil {
+			return nil, err
+		}
+		defs[table.TypeName] = tableSchema(table)
+	}
+	return json.Marshal(map[string]interface{}{
+		"$schema":     draft,
+		"definitions": defs,
+	})
+}
+
+func modelTable(model interface{}) (*schema.Table, error) {
+	typ := reflect.TypeOf(model)
+	for typ != nil && (typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice) {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bunschema: got %T, wanted a struct or a struct slice", model)
+	}
+	return tables.Get(typ), nil
+}
+
+func tableSchema(table *schema.Table) map[string]interface{} {
+	props := make(map[string]interface{}, len(table.Fields))
+	required := make([]string, 0)
+
+	for _, field := range table.Fields {
+		props[field.Name] = fieldSchema(field)
+		if field.NotNull {
+			required = append(required, field.Name)
+		}
+	}
+
+	return map[string]interface{}{
+		"title":      table.Name,
+		"type":       "object",
+		"properties": props,
+		"required":   required,
+	}
+}
+
+func fieldSchema(field *schema.Field) map[string]interface{} {
+	m := make(map[string]interface{})
+
+	typ, format := fieldType(field)
+	if typ != "" {
+		// Columns are created without NOT NULL unless the field has the notnull option.
+		if !field.NotNull {
+			m["type"] = []string{typ, "null"}
+		} else {
+			m["type"] = typ
+		}
+	}
+	if format != "" {
+		m["format"] = format
+	}
+	if field.SQLDefault != "" {
+		m["x-sql-default"] = field.SQLDefault
+	}
+	return m
+}
+
+func fieldType(field *schema.Field) (typ, format string) {
+	sqlType := field.DiscoveredSQLType
+	if field.UserSQLType != "" {
+		sqlType = strings.ToUpper(field.UserSQLType)
+	}
+
+	switch sqlType {
+	case sqltype.Boolean:
+		return "boolean", ""
+	case sqltype.SmallInt, sqltype.Integer, sqltype.BigInt:
+		return "integer", ""
+	case sqltype.Real, sqltype.DoublePrecision:
+		return "number", ""
+	case sqltype.Timestamp:
+		return "string", "date-time"
+	}
+	return kindType(field.IndirectType), ""
+}
+
+func kindType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+//go:build go1.18
+// +build go1.18
+
+package bun_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+func FuzzAppendQuery(f *testing.F) {
+	for _, args := range [][2]string{
+		{"name", "value"},
+		{"users.name", "it's"},
+		{`na"me`, `"value"`},
+		{"*", "?"},
+		{"'; DROP TABLE users; --", "'; DROP TABLE users; --"},
+	} {
+		f.Add(args[0], args[1])
+	}
+
+	db := bun.NewDB(nil, schema.NewNopDialect())
+
+	queries := []func(ident, value string) schema.QueryAppender{
+		func(ident, value string) schema.QueryAppender {
+			return db.NewSelect().
+				Column(ident).
+				ColumnExpr("?", bun.Ident(ident)).
+				TableExpr("?", bun.Ident(ident)).
+				Where("? = ?", bun.Ident(ident), value).
+				WhereOr("? IN (?)", bun.Ident(ident), bun.In([]string{value, value})).
+				OrderExpr("? DESC", bun.Ident(ident))
+		},
+		func(ident, value string) schema.QueryAppender {
+			return db.NewUpdate().
+				TableExpr("?", bun.Ident(ident)).
+				Set("? = ?", bun.Ident(ident), value).
+				Where("? = ?", bun.Ident(ident), value)
+		},
+		func(ident, value string) schema.QueryAppender {
+			return db.NewDelete().
+				TableExpr("?", bun.Ident(ident)).
+				Where("? = ?", bun.Ident(ident), value)
+		},
+	}
+
+	f.Fuzz(func(t *testing.T, ident, value string) {
+		if ident == "" {
+			// Empty columns are omitted and change the query shape.
+			return
+		}
+		for _, query := range queries {
+			want := appendQuery(t, db.Formatter(), query("ident", "value"))
+			got := appendQuery(t, db.Formatter(), query(ident, value))
+			if got != want {
+				t.Fatalf("unquoted input in query:\n%s\nwanted:\n%s", got, want)
+			}
+		}
+	})
+}
+
+// appendQuery formats the query and returns the parts that are not quoted.
+// Dots and stars are also removed, because identifiers are split on them.
+func appendQuery(t *testing.T, fmter schema.Formatter, query schema.QueryAppender) string {
+	b, err := query.AppendQuery(fmter, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, ok := stripQuoted(string(b))
+	if !ok {
+		t.Fatalf("query has an unclosed quote: %s", b)
+	}
+	return strings.NewReplacer(".", "", "*", "").Replace(s)
+}
+
+// stripQuoted removes quoted identifiers and string literals from the query.
+// It returns false if a quote is not closed.
+func stripQuoted(query string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(query); i++ {
+		quote := query[i]
+		if quote != '"' && quote != '\'' {
+			b.WriteByte(quote)
+			continue
+		}
+
+		closed := false
+		for i++; i < len(query); i++ {
+			if query[i] != quote {
+				continue
+			}
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			closed = true
+			break
+		}
+		if !closed {
+			return "", false
+		}
+	}
+	return b.String(), true
+}
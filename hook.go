@@ -3,6 +3,7 @@ package bun
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"reflect"
 	"sync/atomic"
 	"time"
@@ -14,6 +15,7 @@ type QueryEvent struct {
 	DB *DB
 
 	QueryAppender schema.QueryAppender
+	Model         Model // nil if the query has no model
 	Query         string
 	QueryArgs     []interface{}
 
@@ -45,6 +47,7 @@ func (db *DB) beforeQuery(
 		DB: db,
 
 		QueryAppender: queryApp,
+		Model:         queryModel(queryApp),
 		Query:         query,
 		QueryArgs:     queryArgs,
 
@@ -87,6 +90,64 @@ func (db *DB) afterQueryFromIndex(ctx context.Context, event *QueryEvent, hookIn
 	}
 }
 
+// isSelectQuery reports whether the query only reads data.
+func isSelectQuery(queryApp schema.QueryAppender) bool {
+	switch queryApp.(type) {
+	case *SelectQuery, countQuery, selectExistsQuery:
+		return true
+	default:
+		return false
+	}
+}
+
+func queryModel(queryApp schema.QueryAppender) Model {
+	if q, ok := queryApp.(interface{ GetModel() Model }); ok {
+		return q.GetModel()
+	}
+	return nil
+}
+
+// modelType returns the struct type of the query model or nil.
+func (e *QueryEvent) modelType() reflect.Type {
+	if tm, ok := e.Model.(tableModel); ok {
+		return tm.Table().Type
+	}
+	return nil
+}
+
+// AddModelHook adds a query hook that is only called for queries
+// that use the model type, for example, (*User)(nil) or []User.
+func (db *DB) AddModelHook(model interface{}, hook QueryHook) {
+	typ := reflect.TypeOf(model)
+	for typ != nil && (typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice) {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(fmt.Errorf("bun: AddModelHook(unsupported %T)", model))
+	}
+	db.AddQueryHook(modelQueryHook{typ: typ, hook: hook})
+}
+
+type modelQueryHook struct {
+	typ  reflect.Type
+	hook QueryHook
+}
+
+var _ QueryHook = (*modelQueryHook)(nil)
+
+func (h modelQueryHook) BeforeQuery(ctx context.Context, event *QueryEvent) context.Context {
+	if event.modelType() != h.typ {
+		return ctx
+	}
+	return h.hook.BeforeQuery(ctx, event)
+}
+
+func (h modelQueryHook) AfterQuery(ctx context.Context, event *QueryEvent) {
+	if event.modelType() == h.typ {
+		h.hook.AfterQuery(ctx, event)
+	}
+}
+
 //------------------------------------------------------------------------------
 
 func callBeforeScanHook(ctx context.Context, v reflect.Value) error {
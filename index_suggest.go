@@ -0,0 +1,238 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// SuggestIndexes runs EXPLAIN on the query and returns CREATE INDEX statements
+// for the tables that could benefit from an index. On PostgreSQL it looks for
+// sequential scans with a filter and on MySQL for "Using filesort" and
+// "Using temporary" in the Extra column.
+//
+// The suggestions are based on simple heuristics and should be reviewed
+// before they are applied. It is meant to be used during development.
+func (db *DB) SuggestIndexes(ctx context.Context, q *SelectQuery) ([]string, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	queryBytes, err := q.AppendQuery(db.fmter, nil)
+	if err != nil {
+		return nil, err
+	}
+	query := internal.String(queryBytes)
+
+	var indexes []indexSuggestion
+	switch name := db.dialect.Name(); name {
+	case dialect.PG:
+		indexes, err = db.suggestPGIndexes(ctx, q.conn, query)
+	case dialect.MySQL5, dialect.MySQL8:
+		indexes, err = db.suggestMySQLIndexes(ctx, q, query)
+	default:
+		return nil, fmt.Errorf("bun: SuggestIndexes is not supported by %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(indexes))
+	stmts := make([]string, 0, len(indexes))
+	for _, idx := range indexes {
+		stmt := idx.createIndex(db)
+		if _, ok := seen[stmt]; ok {
+			continue
+		}
+		seen[stmt] = struct{}{}
+		stmts = append(stmts, stmt)
+	}
+	return stmts, nil
+}
+
+type indexSuggestion struct {
+	table   string
+	columns []string
+}
+
+func (idx indexSuggestion) createIndex(db *DB) string {
+	name := strings.ReplaceAll(idx.table, ".", "_") + "_" + strings.Join(idx.columns, "_") + "_idx"
+
+	b := []byte("CREATE INDEX ")
+	b = db.fmter.AppendIdent(b, name)
+	b = append(b, " ON "...)
+	b = db.fmter.AppendIdent(b, idx.table)
+	b = append(b, " ("...)
+	for i, col := range idx.columns {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = db.fmter.AppendIdent(b, col)
+	}
+	b = append(b, ')')
+	return string(b)
+}
+
+//------------------------------------------------------------------------------
+
+type pgPlanNode struct {
+	NodeType     string       `json:"Node Type"`
+	RelationName string       `json:"Relation Name"`
+	Schema       string       `json:"Schema"`
+	Filter       string       `json:"Filter"`
+	Plans        []pgPlanNode `json:"Plans"`
+}
+
+var pgFilterColumnRE = regexp.MustCompile(
+	`(?:^|[(\s])(?:"?\w+"?\.)?"?([a-z_][a-z0-9_]*)"?\)?(?:::[\w ]+?)?\s*(?:=|<>|!=|<=|>=|<|>|!?~~\*?|IS\s)`)
+
+func (db *DB) suggestPGIndexes(ctx context.Context, conn IConn, query string) ([]indexSuggestion, error) {
+	var planJSON string
+	if err := conn.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+query).Scan(&planJSON); err != nil {
+		return nil, err
+	}
+
+	var plans []struct {
+		Plan pgPlanNode `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(planJSON), &plans); err != nil {
+		return nil, fmt.Errorf("bun: can't parse EXPLAIN output: %w", err)
+	}
+
+	var indexes []indexSuggestion
+	for i := range plans {
+		indexes = appendPGSeqScans(indexes, &plans[i].Plan)
+	}
+	return indexes, nil
+}
+
+func appendPGSeqScans(indexes []indexSuggestion, node *pgPlanNode) []indexSuggestion {
+	if node.NodeType == "Seq Scan" && node.RelationName != "" && node.Filter != "" {
+		if columns := filterColumns(node.Filter); len(columns) > 0 {
+			table := node.RelationName
+			if node.Schema != "" && node.Schema != "public" {
+				table = node.Schema + "." + table
+			}
+			indexes = append(indexes, indexSuggestion{table: table, columns: columns})
+		}
+	}
+	for i := range node.Plans {
+		indexes = appendPGSeqScans(indexes, &node.Plans[i])
+	}
+	return indexes
+}
+
+func filterColumns(filter string) []string {
+	var columns []string
+	for _, m := range pgFilterColumnRE.FindAllStringSubmatch(filter, -1) {
+		columns = appendUnique(columns, m[1])
+	}
+	return columns
+}
+
+//------------------------------------------------------------------------------
+
+var orderColumnRE = regexp.MustCompile(
+	"(?i)^(?:[`\"]?(\\w+)[`\"]?\\.)?[`\"]?(\\w+)[`\"]?(?:\\s+(?:ASC|DESC).*)?$")
+
+func (db *DB) suggestMySQLIndexes(
+	ctx context.Context, q *SelectQuery, query string,
+) ([]indexSuggestion, error) {
+	rows, err := q.conn.QueryContext(ctx, "EXPLAIN "+query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []indexSuggestion
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		var alias, extra string
+		for i, col := range columns {
+			switch strings.ToLower(col) {
+			case "table":
+				alias = values[i].String
+			case "extra":
+				extra = values[i].String
+			}
+		}
+		if alias == "" {
+			continue
+		}
+
+		var exprs []string
+		if strings.Contains(extra, "Using filesort") {
+			exprs = q.formatExprs(q.order)
+		}
+		if strings.Contains(extra, "Using temporary") {
+			exprs = append(exprs, q.formatExprs(q.group)...)
+		}
+
+		var cols []string
+		for _, expr := range exprs {
+			m := orderColumnRE.FindStringSubmatch(strings.TrimSpace(expr))
+			if m == nil || (m[1] != "" && m[1] != alias) {
+				continue
+			}
+			cols = appendUnique(cols, m[2])
+		}
+		if len(cols) > 0 {
+			indexes = append(indexes, indexSuggestion{
+				table:   q.tableNameByAlias(alias),
+				columns: cols,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
+
+func (q *SelectQuery) formatExprs(exprs []schema.QueryWithArgs) []string {
+	ss := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		b, err := expr.AppendQuery(q.db.fmter, nil)
+		if err != nil {
+			continue
+		}
+		ss = append(ss, string(b))
+	}
+	return ss
+}
+
+func (q *SelectQuery) tableNameByAlias(alias string) string {
+	if q.table != nil && q.table.Alias == alias {
+		return q.table.Name
+	}
+	return alias
+}
+
+func appendUnique(ss []string, s string) []string {
+	for _, el := range ss {
+		if el == s {
+			return ss
+		}
+	}
+	return append(ss, s)
+}
@@ -18,6 +18,8 @@ replace github.com/uptrace/bun/dialect/sqlitedialect => ../../dialect/sqlitedial
 
 replace github.com/uptrace/bun/extra/bundebug => ../../extra/bundebug
 
+replace github.com/uptrace/bun/extra/bunschema => ../../extra/bunschema
+
 require (
 	github.com/bradleyjkemp/cupaloy v2.3.0+incompatible
 	github.com/brianvoe/gofakeit/v6 v6.4.1
@@ -33,4 +35,6 @@ require (
 	github.com/uptrace/bun/driver/pgdriver v0.4.0
 	github.com/uptrace/bun/driver/sqliteshim v0.4.0
 	github.com/uptrace/bun/extra/bundebug v0.4.0
+	github.com/uptrace/bun/extra/bunschema v0.4.0
+	go.uber.org/goleak v1.1.11
 )
@@ -0,0 +1,196 @@
+package dbtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+func TestMigrate(t *testing.T) {
+	type Test struct {
+		name string
+		run  func(t *testing.T, db *bun.DB)
+	}
+
+	tests := []Test{
+		{"testPlanDown", testPlanDown},
+		{"testMigrateHooks", testMigrateHooks},
+		{"testBeforeMigrateError", testBeforeMigrateError},
+		{"testAfterMigrateError", testAfterMigrateError},
+	}
+
+	testEachDB(t, func(t *testing.T, db *bun.DB) {
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				test.run(t, db)
+			})
+		}
+	})
+}
+
+// newMigrator returns an initialized migrator that uses its own tables,
+// which are dropped when the test ends.
+func newMigrator(
+	t *testing.T, db *bun.DB, migrations *migrate.Migrations, opts ...migrate.MigratorOption,
+) *migrate.Migrator {
+	opts = append([]migrate.MigratorOption{
+		migrate.WithTableName("test_migrations"),
+		migrate.WithLocksTableName("test_migration_locks"),
+	}, opts...)
+	migrator := migrate.NewMigrator(db, migrations, opts...)
+
+	for _, table := range []string{"test_migrations", "test_migration_locks"} {
+		_, err := db.NewDropTable().Table(table).IfExists().Exec(ctx)
+		require.NoError(t, err)
+
+		table := table
+		t.Cleanup(func() {
+			_, err := db.NewDropTable().Table(table).IfExists().Exec(ctx)
+			require.NoError(t, err)
+		})
+	}
+	require.NoError(t, migrator.Init(ctx))
+
+	return migrator
+}
+
+func testPlanDown(t *testing.T, db *bun.DB) {
+	migrations := migrate.NewMigrations(migrate.WithFS(fstest.MapFS{
+		"20210101000000_first.up.sql":    {Data: []byte("SELECT 1")},
+		"20210101000000_first.down.sql":  {Data: []byte("SELECT 10")},
+		"20210102000000_second.up.sql":   {Data: []byte("SELECT 2")},
+		"20210102000000_second.down.sql": {Data: []byte("SELECT 20\n--bun:split\nSELECT 21")},
+		"20210103000000_third.up.sql":    {Data: []byte("SELECT 3")},
+	}))
+	migrator := newMigrator(t, db, migrations)
+
+	_, err := migrator.Migrate(ctx)
+	require.NoError(t, err)
+
+	plans, err := migrator.PlanDown(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, plans, 2)
+	require.Equal(t, "20210103000000", plans[0].Migration.Name)
+	require.Nil(t, plans[0].Queries)
+	require.Equal(t, "20210102000000", plans[1].Migration.Name)
+	require.Equal(t, []string{"SELECT 20\n", "SELECT 21\n"}, plans[1].Queries)
+
+	plans, err = migrator.PlanDown(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, plans, 3)
+	require.Equal(t, "20210101000000", plans[2].Migration.Name)
+	require.Equal(t, []string{"SELECT 10\n"}, plans[2].Queries)
+
+	_, err = migrator.PlanDown(ctx, 0)
+	require.Error(t, err)
+
+	// Planning does not roll back the migrations.
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, ms.Applied(), 3)
+}
+
+func testMigrateHooks(t *testing.T, db *bun.DB) {
+	migrations := migrate.NewMigrations(migrate.WithFS(fstest.MapFS{
+		"20210101000000_first.up.sql":  {Data: []byte("SELECT 1")},
+		"20210102000000_second.up.sql": {Data: []byte("SELECT 2")},
+	}))
+
+	var calls []string
+	migrator := newMigrator(t, db, migrations,
+		migrate.BeforeMigrate(func(ctx context.Context, db *bun.DB, name string) error {
+			calls = append(calls, "before1 "+name)
+			return nil
+		}),
+		migrate.BeforeMigrate(func(ctx context.Context, db *bun.DB, name string) error {
+			calls = append(calls, "before2 "+name)
+			return nil
+		}),
+		migrate.AfterMigrate(func(ctx context.Context, db *bun.DB, name string, err error) {
+			require.NoError(t, err)
+			calls = append(calls, "after "+name)
+		}),
+	)
+
+	group, err := migrator.Migrate(ctx)
+	require.NoError(t, err)
+	require.Len(t, group.Migrations, 2)
+	require.Equal(t, []string{
+		"before1 20210101000000",
+		"before2 20210101000000",
+		"after 20210101000000",
+		"before1 20210102000000",
+		"before2 20210102000000",
+		"after 20210102000000",
+	}, calls)
+
+	// Hooks are not called when there is nothing to migrate.
+	calls = nil
+	_, err = migrator.Migrate(ctx)
+	require.NoError(t, err)
+	require.Nil(t, calls)
+}
+
+func testBeforeMigrateError(t *testing.T, db *bun.DB) {
+	migrations := migrate.NewMigrations(migrate.WithFS(fstest.MapFS{
+		"20210101000000_first.up.sql":  {Data: []byte("SELECT 1")},
+		"20210102000000_second.up.sql": {Data: []byte("SELECT 2")},
+	}))
+
+	hookErr := errors.New("before migrate failed")
+	var afterCalled bool
+	migrator := newMigrator(t, db, migrations,
+		migrate.BeforeMigrate(func(ctx context.Context, db *bun.DB, name string) error {
+			if name == "20210102000000" {
+				return hookErr
+			}
+			return nil
+		}),
+		migrate.AfterMigrate(func(ctx context.Context, db *bun.DB, name string, err error) {
+			require.Equal(t, "20210101000000", name)
+			afterCalled = true
+		}),
+	)
+
+	_, err := migrator.Migrate(ctx)
+	require.Equal(t, hookErr, err)
+	require.True(t, afterCalled)
+
+	// Only the first migration is applied.
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	require.NoError(t, err)
+	applied := ms.Applied()
+	require.Len(t, applied, 1)
+	require.Equal(t, "20210101000000", applied[0].Name)
+}
+
+func testAfterMigrateError(t *testing.T, db *bun.DB) {
+	migrations := migrate.NewMigrations(migrate.WithFS(fstest.MapFS{
+		"20210101000000_first.up.sql":  {Data: []byte("SELECT * FROM migrate_missing_table")},
+		"20210102000000_second.up.sql": {Data: []byte("SELECT 2")},
+	}))
+
+	var names []string
+	var afterErr error
+	migrator := newMigrator(t, db, migrations,
+		migrate.AfterMigrate(func(ctx context.Context, db *bun.DB, name string, err error) {
+			names = append(names, name)
+			afterErr = err
+		}),
+	)
+
+	_, err := migrator.Migrate(ctx)
+	require.Error(t, err)
+	require.Equal(t, []string{"20210101000000"}, names)
+	require.Equal(t, err, afterErr)
+
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, ms.Applied(), 0)
+}
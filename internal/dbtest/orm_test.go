@@ -13,7 +13,7 @@ import (
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dbfixture"
-	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/feature"
 )
 
 func TestORM(t *testing.T) {
@@ -222,7 +222,7 @@ func testTranslationRelations(t *testing.T, db *bun.DB) {
 }
 
 func testBulkUpdate(t *testing.T, db *bun.DB) {
-	if db.Dialect().Name() == dialect.MySQL5 {
+	if !db.Dialect().SupportsFeature(feature.CTE) {
 		t.Skip()
 	}
 
@@ -257,6 +257,120 @@ func testBulkUpdate(t *testing.T, db *bun.DB) {
 	}
 }
 
+func TestORMHasManyBatch(t *testing.T) {
+	type Child struct {
+		ID       int64
+		ParentID int64
+	}
+
+	type Parent struct {
+		ID       int64
+		Children []*Child `bun:"rel:has-many"`
+	}
+
+	testEachDB(t, func(t *testing.T, db *bun.DB) {
+		err := db.ResetModel(ctx, (*Parent)(nil), (*Child)(nil))
+		require.NoError(t, err)
+
+		parents := make([]Parent, 100)
+		children := make([]Child, 0, 2*len(parents))
+		for i := range parents {
+			parents[i].ID = int64(i + 1)
+			children = append(children,
+				Child{ID: int64(2*i + 1), ParentID: parents[i].ID},
+				Child{ID: int64(2*i + 2), ParentID: parents[i].ID})
+		}
+
+		_, err = db.NewInsert().Model(&parents).Exec(ctx)
+		require.NoError(t, err)
+
+		_, err = db.NewInsert().Model(&children).Exec(ctx)
+		require.NoError(t, err)
+
+		var numQuery int
+		db.AddQueryHook(&queryHook{
+			beforeQuery: func(ctx context.Context, event *bun.QueryEvent) context.Context {
+				numQuery++
+				return ctx
+			},
+		})
+
+		var selected []Parent
+		err = db.NewSelect().
+			Model(&selected).
+			Relation("Children", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.OrderExpr("child.id ASC")
+			}).
+			OrderExpr("parent.id ASC").
+			Scan(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, numQuery, "has-many relation must be loaded with a single query")
+
+		require.Len(t, selected, len(parents))
+		for i := range selected {
+			require.Len(t, selected[i].Children, 2)
+			require.Equal(t, selected[i].ID, selected[i].Children[0].ParentID)
+			require.Equal(t, selected[i].ID, selected[i].Children[1].ParentID)
+		}
+	})
+}
+
+func TestORMHasManyThrough(t *testing.T) {
+	type User struct {
+		ID   int64
+		Name string
+	}
+
+	type OrgMember struct {
+		OrgID  int64 `bun:",pk"`
+		UserID int64 `bun:",pk"`
+		Role   string
+	}
+
+	type Org struct {
+		ID     int64
+		Admins []*User `bun:"rel:has-many,through:OrgMember,join_fk:org_id,join_ref:user_id"`
+	}
+
+	testEachDB(t, func(t *testing.T, db *bun.DB) {
+		db.RegisterModel((*OrgMember)(nil))
+
+		err := db.ResetModel(ctx, (*Org)(nil), (*User)(nil), (*OrgMember)(nil))
+		require.NoError(t, err)
+
+		orgs := []Org{{ID: 1}, {ID: 2}}
+		users := []User{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob"}, {ID: 3, Name: "carol"}}
+		members := []OrgMember{
+			{OrgID: 1, UserID: 1, Role: "admin"},
+			{OrgID: 1, UserID: 2, Role: "member"},
+			{OrgID: 2, UserID: 2, Role: "admin"},
+			{OrgID: 2, UserID: 3, Role: "admin"},
+		}
+
+		for _, model := range []interface{}{&orgs, &users, &members} {
+			_, err := db.NewInsert().Model(model).Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		var selected []Org
+		err = db.NewSelect().
+			Model(&selected).
+			Relation("Admins", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.Where("org_member.role = ?", "admin").OrderExpr("user.id ASC")
+			}).
+			OrderExpr("org.id ASC").
+			Scan(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, selected, 2)
+		require.Len(t, selected[0].Admins, 1)
+		require.Equal(t, "alice", selected[0].Admins[0].Name)
+		require.Len(t, selected[1].Admins, 2)
+		require.Equal(t, "bob", selected[1].Admins[0].Name)
+		require.Equal(t, "carol", selected[1].Admins[1].Name)
+	})
+}
+
 type Genre struct {
 	ID     int
 	Name   string
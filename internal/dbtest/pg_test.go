@@ -1,6 +1,7 @@
 package dbtest_test
 
 import (
+	"context"
 	"database/sql"
 	"net"
 	"reflect"
@@ -273,3 +274,203 @@ func TestIPNet(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, *ipv4Net, model.Network)
 }
+
+func TestPGEnum(t *testing.T) {
+	type Mood string
+
+	type Model struct {
+		ID   int64
+		Mood Mood `bun:",pgenum:mood"`
+	}
+
+	db := pg(t)
+	db.Dialect().(*pgdialect.Dialect).RegisterEnum("mood", pgdialect.Enum("sad", "ok", "happy"))
+
+	_, err := db.NewDropTable().Model((*Model)(nil)).IfExists().WithEnumTypes().Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewCreateTable().Model((*Model)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	model1 := &Model{ID: 1, Mood: "happy"}
+	_, err = db.NewInsert().Model(model1).Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewInsert().Model(&Model{ID: 2, Mood: "angry"}).Exec(ctx)
+	require.Error(t, err)
+
+	model2 := new(Model)
+	err = db.NewSelect().Model(model2).Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, model1, model2)
+
+	typeExists := func() bool {
+		var exists bool
+		err := db.NewSelect().
+			ColumnExpr("EXISTS (SELECT 1 FROM pg_type WHERE typname = 'mood')").
+			Scan(ctx, &exists)
+		require.NoError(t, err)
+		return exists
+	}
+
+	_, err = db.NewDropTable().Model((*Model)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	require.True(t, typeExists())
+
+	_, err = db.NewCreateTable().Model((*Model)(nil)).IfNotExists().Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewDropTable().Model((*Model)(nil)).WithEnumTypes().Exec(ctx)
+	require.NoError(t, err)
+
+	require.False(t, typeExists())
+}
+
+func TestPGTableSample(t *testing.T) {
+	type Model struct {
+		ID int64
+	}
+
+	db := pg(t)
+
+	_, err := db.NewDropTable().Model((*Model)(nil)).IfExists().Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewCreateTable().Model((*Model)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewInsert().Model(&[]Model{{ID: 1}, {ID: 2}, {ID: 3}}).Exec(ctx)
+	require.NoError(t, err)
+
+	var models []Model
+	err = db.NewSelect().Model(&models).TableSample("BERNOULLI", "100").Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, models, 3)
+
+	models = nil
+	err = db.NewSelect().Model(&models).TableSample("SYSTEM", "0").Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, models, 0)
+}
+
+func TestPGSkipLocked(t *testing.T) {
+	type Model struct {
+		ID int64
+	}
+
+	db := pg(t)
+
+	err := db.ResetModel(ctx, (*Model)(nil))
+	require.NoError(t, err)
+
+	_, err = db.NewInsert().Model(&[]Model{{ID: 1}, {ID: 2}}).Exec(ctx)
+	require.NoError(t, err)
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	locked := new(Model)
+	err = tx.NewSelect().Model(locked).Where("id = 1").For("UPDATE").Scan(ctx)
+	require.NoError(t, err)
+
+	var models []Model
+	err = db.NewSelect().Model(&models).For("UPDATE").SkipLocked().Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Model{{ID: 2}}, models)
+
+	err = db.NewSelect().Model(&models).Where("id = 1").For("UPDATE").NoWait().Scan(ctx)
+	require.Error(t, err)
+}
+
+func TestPGForShare(t *testing.T) {
+	type Model struct {
+		ID int64
+	}
+
+	db := pg(t)
+
+	err := db.ResetModel(ctx, (*Model)(nil))
+	require.NoError(t, err)
+
+	_, err = db.NewInsert().Model(&[]Model{{ID: 1}, {ID: 2}}).Exec(ctx)
+	require.NoError(t, err)
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	var models []Model
+	count, err := tx.NewSelect().Model(&models).ForShare().Limit(1).ScanAndCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Len(t, models, 1)
+
+	models = nil
+	count, err = tx.NewSelect().Model(&models).ForKeyShare().ScanAndCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Len(t, models, 2)
+}
+
+func TestPGParallelWorkers(t *testing.T) {
+	db := pg(t)
+
+	err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var settings struct {
+			Workers string
+			WorkMem string
+		}
+		err := tx.NewSelect().
+			ColumnExpr("current_setting('max_parallel_workers_per_gather') AS workers").
+			ColumnExpr("current_setting('work_mem') AS work_mem").
+			PGParallelWorkers(3).
+			PGWorkMem("64MB").
+			Scan(ctx, &settings)
+		require.NoError(t, err)
+		require.Equal(t, "3", settings.Workers)
+		require.Equal(t, "64MB", settings.WorkMem)
+		return nil
+	})
+	require.NoError(t, err)
+
+	var num int
+	err = db.NewSelect().ColumnExpr("1").PGWorkMem("64MB").Scan(ctx, &num)
+	require.EqualError(t, err, "bun: PGParallelWorkers and PGWorkMem require a transaction")
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	err = conn.NewSelect().ColumnExpr("1").PGWorkMem("64MB").Scan(ctx, &num)
+	require.EqualError(t, err, "bun: PGParallelWorkers and PGWorkMem require a transaction")
+}
+
+func TestPGCreateSchema(t *testing.T) {
+	type Model struct {
+		bun.BaseModel `bun:"bun_test_schema.models"`
+
+		ID int64
+	}
+
+	db := pg(t)
+
+	_, err := db.NewDropSchema("bun_test_schema").IfExists().Cascade().Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewCreateSchema("bun_test_schema").Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewCreateSchema("bun_test_schema").IfNotExists().Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewCreateTable().Model((*Model)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewDropSchema("bun_test_schema").Restrict().Exec(ctx)
+	require.Error(t, err)
+
+	_, err = db.NewDropSchema("bun_test_schema").Cascade().Exec(ctx)
+	require.NoError(t, err)
+}
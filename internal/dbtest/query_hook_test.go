@@ -2,6 +2,7 @@ package dbtest_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -70,6 +71,45 @@ func testQueryHook(t *testing.T, db *bun.DB) {
 	}
 }
 
+func TestModelQueryHook(t *testing.T) {
+	testEachDB(t, testModelQueryHook)
+}
+
+type queryHookModel struct {
+	ID int64
+}
+
+func testModelQueryHook(t *testing.T, db *bun.DB) {
+	var models []string
+	hook := &queryHook{
+		beforeQuery: func(ctx context.Context, event *bun.QueryEvent) context.Context {
+			models = append(models, fmt.Sprintf("%T", event.Model.Value()))
+			return ctx
+		},
+	}
+	db.AddModelHook((*queryHookModel)(nil), hook)
+
+	var model queryHookModel
+	err := db.NewSelect().
+		Model(&model).
+		ModelTableExpr("(SELECT 1 AS id) AS query_hook_model").
+		Scan(ctx)
+	require.NoError(t, err)
+	hook.require(t)
+
+	var slice []queryHookModel
+	err = db.NewSelect().
+		Model(&slice).
+		ModelTableExpr("(SELECT 1 AS id) AS query_hook_model").
+		Scan(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewSelect().ColumnExpr("1").Exec(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"*dbtest_test.queryHookModel", "*[]dbtest_test.queryHookModel"}, models)
+}
+
 type queryHook struct {
 	startTime time.Time
 	endTime   time.Time
@@ -466,6 +466,544 @@ func TestQuery(t *testing.T) {
 			}
 			return db.NewSelect().Where("?a + ?b AS ?alias", params)
 		},
+		func(db *bun.DB) schema.QueryAppender {
+			lo := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+			hi := time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)
+			return db.NewSelect().
+				Model(new(Model)).
+				WhereBetween("model.id", 1, 10).
+				WhereNotBetween("created_at", lo, hi)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewCreateTable().Model(new(Model)).InheritsFrom("parents")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().OnlyTable("parents").Where("id = 1")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).Sample(10)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type Source struct {
+				bun.BaseModel `bun:"sources,alias:src"`
+
+				ID  int64
+				Str string
+			}
+
+			return db.NewUpdate().Model(new(Model)).FromModel((*Source)(nil))
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewUpdate().
+				Model(new(Model)).
+				From("sources AS src").
+				Set("str = src.str").
+				Where("model.id = src.id")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				Model(new(Story)).
+				Relation("User").
+				Lock(bun.LockForUpdate|bun.LockSkipLocked, "story")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).Lock(bun.LockForKeyShare)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type Model struct {
+				ID    int64
+				Str   bun.NullString
+				Int   bun.NullInt64
+				Float bun.NullFloat64
+				Bool  bun.NullBool
+			}
+
+			model := &Model{ID: 1}
+			model.Int.Int64, model.Int.Valid = 42, true
+			model.Bool.Bool, model.Bool.Valid = true, true
+			return db.NewInsert().Model(model)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type Model struct {
+				ID   int64
+				UUID string `bun:"type:uuid,default:gen_random_uuid()"`
+				Str  string
+			}
+
+			return db.NewInsert().
+				Model(&Model{ID: 1, Str: "hello"}).
+				SetDefault("uuid").
+				SetDefault("str")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				Model(new(Model)).
+				Column("id", "str").
+				Groups("id", "str").
+				GroupExprs("lower(str)", "upper(str)")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			q1 := db.NewSelect().Model(new(Model)).Order("id")
+			q2 := db.NewSelect().Model(new(Model)).Order("str").Limit(1)
+			q3 := db.NewSelect().Model(new(Model)).Order("id")
+			q4 := db.NewSelect().Model(new(Model)).Order("str")
+			return q1.Union(q2).UnionAll(q3).Except(q4)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).Where("id IN (?)", bun.In([]int{1, 2})).LockOrdered("id")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewInsert().
+				Model(&Model{ID: 42, Str: "hello"}).
+				On("CONFLICT (id) DO UPDATE").
+				Set("str = model.str || EXCLUDED.str").
+				Set("id = model.id + ?", 1)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).Where("id = 1").For("UPDATE").LockTimeout(0)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).Where("id = 1").For("UPDATE").LockTimeout(time.Second)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				Model(new(Model)).
+				WhereNull("model.str").
+				WhereOrNotNull("id").
+				WhereNotNull("str").
+				WhereOrNull("id")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			models := []*Model{{ID: 1}, {ID: 2}}
+			return db.NewUpdate().
+				Model(new(Model)).
+				Set("str = 'updated'").
+				WhereModel(models)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type Composite struct {
+				TenantID int64 `bun:",pk"`
+				ID       int64 `bun:",pk"`
+				Str      string
+			}
+
+			models := []Composite{{TenantID: 1, ID: 1}, {TenantID: 1, ID: 2}}
+			return db.NewUpdate().
+				Model(new(Composite)).
+				Set("str = 'updated'").
+				WhereModel(&models)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				ColumnExpr("?", bun.Cast("str", "int")).
+				Model(new(Model)).
+				Where("? > 0", bun.Cast("model.str", "int")).
+				OrderExpr("? DESC", bun.Cast("str", "varchar(10)"))
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type User struct {
+				ID     int64
+				Name   string
+				Status string
+				Email  string
+			}
+
+			return db.NewUpdate().
+				Model(&User{ID: 1, Name: "", Status: "active", Email: "a@b.c"}).
+				Only("name", "status").
+				WherePK()
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewUpdate().Model(&Model{ID: 1}).Only("id").WherePK()
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			q1 := db.NewSelect().Model(new(Model)).Where("id = 1")
+			q2 := db.NewSelect().Model(new(Model)).Where("id = 2")
+			q3 := db.NewSelect().Model(new(Model)).Where("id = 3")
+			q4 := db.NewSelect().Model(new(Model)).Where("id = 4")
+			return db.NewSelect().Model(new(Model)).
+				Union(q1).
+				Except(q2).
+				Intersect(q3).
+				IntersectAll(q4)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			q1 := db.NewSelect().Model(new(Model)).Where("id = 1")
+			q2 := db.NewSelect().Model(new(Model)).Where("id = 2")
+			q3 := db.NewSelect().Model(new(Model)).Where("id = 3")
+			return db.NewSelect().Model(new(Model)).
+				Intersect(q1).
+				ExceptAll(q2).
+				Intersect(q3)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewInsert().
+				Model(&Model{ID: 123, Str: "hello"}).
+				OnConflictWhere("str", "id > ?", "UPDATE", 100).
+				Set("str = EXCLUDED.str")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				Model(new(Model)).
+				WhereLike("str", "foo%").
+				WhereNotLike("model.str", "%bar").
+				WhereILike("str", "%Baz%").
+				WhereNotILike("str", "qux_")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type Address struct {
+				City string
+			}
+			type User struct {
+				ID       int64
+				Data     map[string]interface{}
+				City     string
+				Address  Address
+				FirstTag string
+			}
+			return db.NewSelect().
+				Model(new(User)).
+				Column("id").
+				ColumnJSON("data", "address.city", "city").
+				ColumnJSON("data", "address", "address").
+				ColumnJSON("user.data", "tags.0", "first_tag").
+				ColumnJSON("data", "weird key", "weird")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewInsert().
+				Model(new(Model)).
+				Select(db.NewSelect().
+					ColumnExpr("id + 100").
+					ColumnExpr("str").
+					TableExpr("models_archive").
+					Where("id < ?", 10))
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewInsert().
+				TableExpr("dest").
+				Column("a", "b").
+				Select(db.NewSelect().Column("a", "b").TableExpr("src"))
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			id := 1
+			q := db.NewSelect().
+				Model(new(Model)).
+				Where("?", schema.LazyQuery("id = ?", func() []interface{} {
+					return []interface{}{id}
+				}))
+			id = 2
+			return q
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type Model struct {
+				ID   int64
+				Data []byte `bun:"type:bytea,pg:bytea,mysql:BLOB,sqlite:BLOB"`
+				Text string `bun:"mysql:TEXT"`
+			}
+			return db.NewCreateTable().Model(new(Model))
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).
+				ApplyIf(true, func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.Where("id > ?", 1)
+				}).
+				ApplyIf(false, func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.Where("id < ?", 100)
+				})
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).
+				WhereIn("id", []int{1}).
+				WhereIn("str", &[]string{"a", "b"})
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type Profile struct {
+				ID     int64
+				Salary int64 `bun:",acl:admin"`
+			}
+			type User struct {
+				ID        int64
+				Name      string
+				SSN       string `bun:",acl:admin"`
+				ProfileID int64
+				Profile   *Profile `bun:"rel:belongs-to"`
+			}
+			return db.WithACL(ctx, "viewer").NewSelect().Model(new(User)).Relation("Profile")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type User struct {
+				ID   int64
+				Name string
+				SSN  string `bun:",acl:admin"`
+			}
+			return db.WithACL(ctx, "viewer").NewSelect().Model(new(User)).Column("id", "ssn", "name")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type User struct {
+				ID   int64
+				Name string
+				SSN  string `bun:",acl:admin"`
+			}
+			return db.WithACL(ctx, "admin").NewSelect().Model(new(User))
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewUpdate().Model(new(SoftDelete)).
+				Set("id = id").
+				Where("id = 1").
+				WhereOr("id = 2").
+				WhereGroup(" OR ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+					return q.Where("id > 3").WhereOr("id < 0")
+				}).
+				WhereAllWithDeleted()
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewDelete().Model(new(SoftDelete)).
+				Where("id = 1").
+				WhereOr("id = 2").
+				WhereGroup(" OR ", func(q *bun.DeleteQuery) *bun.DeleteQuery {
+					return q.Where("id > 3").WhereOr("id < 0")
+				}).
+				WhereAllWithDeleted().
+				ForceDelete()
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).Page(3, 20)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				ColumnExpr("n").
+				TableFunction("generate_series(?, ?)", "n", 1, 3)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				Model(new(Model)).
+				ColumnExpr("t.tag").
+				LateralTableFunction("unnest(string_to_array(?TableAlias.str, ','))", "t")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				Model(new(Model)).
+				ColumnExpr("str").
+				ColumnExpr("rank() OVER w").
+				ColumnExpr("sum(id) OVER w2").
+				Group("str", "id").
+				Having("id > ?", 0).
+				Window("w", "PARTITION BY ? ORDER BY id DESC", bun.Ident("str")).
+				Window("w2", "w ROWS BETWEEN 1 PRECEDING AND CURRENT ROW").
+				OrderExpr("id")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).OrderByField("str", "new", "active", "closed")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				Model(new(Story)).
+				Relation("User").
+				TableExpr("generate_series(1, 2) AS n").
+				TableSample("BERNOULLI", "5")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				TableExpr("big_table AS t").
+				TableExpr("other_table").
+				TableSample("SYSTEM", "1")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).For("UPDATE").SkipLocked()
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).Lock(bun.LockForShare).NoWait()
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).ForShare().SkipLocked()
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).ForKeyShare()
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				ColumnExpr("u.id, p.title").
+				TableExpr("users AS u").
+				JoinLateral(db.NewSelect().
+					ColumnExpr("title").
+					TableExpr("posts").
+					Where("posts.user_id = u.id").
+					OrderExpr("id DESC").
+					Limit(1), "p")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				TableExpr("users AS u").
+				JoinLateral(db.NewSelect().TableExpr("posts").Where("posts.user_id = u.id"), "p").
+				JoinOn("p.title IS NOT NULL")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewCreateSchema("tenant").IfNotExists().Authorization("admin")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewDropSchema("tenant").IfExists().Cascade()
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).AfterCursor("id", 10).Limit(20)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).OrderExpr("id").BeforeCursor("model.id", 10)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				ColumnExpr("author_id, count(*)").
+				TableExpr("books").
+				GroupExpr("author_id").
+				Having("count(*) > ?", 10).
+				HavingOr("sum(pages) > ?", 1000)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				ColumnExpr("author_id").
+				TableExpr("books").
+				GroupExpr("author_id").
+				Having("count(*) > 1").
+				HavingGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.Having("min(pages) > 10").HavingOr("max(pages) < 100")
+				}).
+				HavingGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.Having("avg(pages) = 50")
+				})
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				Model(&Model{ID: 1}).
+				WherePK().
+				Where("str = ?", "hello").
+				ResetWhere().
+				Where("str = ?", "world").
+				Order("id").
+				ResetOrder().
+				Order("str").
+				Limit(10)
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				ColumnExpr("author_id").
+				TableExpr("books").
+				GroupExpr("author_id").
+				Having("count(*) > 1").
+				ResetGroup().
+				ResetHaving().
+				Where("author_id > 0")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).WhereRegexp("str", "^foo[0-9]+$").WhereNotRegexp("str", "bar")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).WhereIRegexp("model.str", "^FOO")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			tree := db.NewSelect().
+				ColumnExpr("id, parent_id").
+				TableExpr("categories").
+				Where("id = ?", 1).
+				UnionAll(db.NewSelect().
+					ColumnExpr("c.id, c.parent_id").
+					TableExpr("categories AS c").
+					Join("JOIN tree ON tree.id = c.parent_id"))
+			return db.NewSelect().
+				With("roots", db.NewSelect().ColumnExpr("1 AS id")).
+				WithRecursive("tree", tree).
+				ColumnExpr("id").
+				TableExpr("tree")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().
+				Model(new(Model)).
+				WhereExists(db.NewSelect().TableExpr("orders").Where("orders.model_id = model.id")).
+				WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.Where("str = 'a'").
+						WhereOr("str = 'b'").
+						WhereNotExists(db.NewSelect().TableExpr("bans").Where("bans.model_id = model.id"))
+				})
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).WhereFilters(map[string]interface{}{
+				"str": "hello",
+				"id":  nil,
+			})
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).WhereFilters(map[string]interface{}{
+				"str; DROP TABLE models": "hello",
+			})
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type Secret struct {
+				SSN string `bun:",acl:admin"`
+			}
+			return db.WithACL(ctx, "viewer").NewSelect().Model(new(Secret))
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).
+				WhereIn("id", []int{}).
+				WhereIn("str", &[]string{})
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).OrderExpr("str DESC").LockOrdered("id")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type User struct {
+				ID   int64
+				Name string
+				SSN  string `bun:",acl:admin"`
+			}
+			return db.WithACL(ctx, "viewer").NewSelect().Model(new(User)).ColumnExpr("*")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type User struct {
+				ID   int64
+				Name string
+				SSN  string `bun:",acl:admin"`
+			}
+			return db.WithACL(ctx, "viewer").NewSelect().Model(new(User)).ColumnExpr("?TableColumns")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type User struct {
+				ID   int64
+				Name string
+				SSN  string `bun:",acl:admin"`
+			}
+			return db.WithACL(ctx, "viewer").NewInsert().Model(&User{Name: "john"}).Returning("*")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type User struct {
+				ID   int64
+				Name string
+				SSN  string `bun:",acl:admin"`
+			}
+			return db.WithACL(ctx, "viewer").NewUpdate().Model(&User{ID: 1, Name: "john"}).
+				WherePK().
+				Returning("*")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type User struct {
+				ID   int64
+				Name string
+				SSN  string `bun:",acl:admin"`
+			}
+			return db.WithACL(ctx, "viewer").NewDelete().Model(&User{ID: 1}).
+				WherePK().
+				Returning("name").
+				Returning("ssn")
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			type Model struct {
+				ID   int64
+				UUID string `bun:"type:uuid,default:gen_random_uuid()"`
+				Str  string
+			}
+			return db.NewInsert().Model(&[]Model{{ID: 1, Str: "hello"}, {ID: 2, UUID: "uuid"}})
+		},
+		func(db *bun.DB) schema.QueryAppender {
+			return db.NewSelect().Model(new(Model)).OrderExpr("model.id DESC, str").BeforeCursor("model.id", 10)
+		},
 	}
 
 	timeRE := regexp.MustCompile(`'\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d+(\+\d{2}:\d{2})?'`)
@@ -41,7 +41,7 @@ func (j *join) Select(ctx context.Context, q *SelectQuery) error {
 	switch j.Relation.Type {
 	case schema.HasManyRelation:
 		return j.selectMany(ctx, q)
-	case schema.ManyToManyRelation:
+	case schema.ManyToManyRelation, schema.HasManyThroughRelation:
 		return j.selectM2M(ctx, q)
 	}
 	panic("not reached")
@@ -93,17 +93,42 @@ func (j *join) manyQuery(q *SelectQuery) *SelectQuery {
 }
 
 func (j *join) hasManyColumns(q *SelectQuery) *SelectQuery {
-	if j.Relation.M2MTable != nil {
-		q = q.ColumnExpr(string(j.Relation.M2MTable.SQLAlias) + ".*")
+	if m2mTable := j.Relation.M2MTable; m2mTable != nil {
+		if q.db.aclRoles == nil {
+			q = q.ColumnExpr(string(m2mTable.SQLAlias) + ".*")
+		} else if fields := q.db.selectableFields(m2mTable.Fields); len(fields) > 0 {
+			q = q.ColumnExpr(internal.String(appendColumns(nil, m2mTable.SQLAlias, fields)))
+		}
 	}
 
 	b := make([]byte, 0, 32)
+	joinTable := j.JoinModel.Table()
 
 	if len(j.columns) > 0 {
-		for i, col := range j.columns {
-			if i > 0 {
+		var n int
+		for _, col := range j.columns {
+			var fields []*schema.Field
+			switch {
+			case q.db.isACLStar(col):
+				fields = q.db.selectableFields(joinTable.Fields)
+				if len(fields) == 0 {
+					continue
+				}
+			case col.Args == nil:
+				if field, ok := joinTable.FieldMap[col.Query]; ok && !q.db.canSelect(field) {
+					continue
+				}
+			}
+
+			if n > 0 {
 				b = append(b, ", "...)
 			}
+			n++
+
+			if fields != nil {
+				b = appendColumns(b, joinTable.SQLAlias, fields)
+				continue
+			}
 
 			var err error
 			b, err = col.AppendQuery(q.db.fmter, b)
@@ -113,11 +138,12 @@ func (j *join) hasManyColumns(q *SelectQuery) *SelectQuery {
 			}
 		}
 	} else {
-		joinTable := j.JoinModel.Table()
-		b = appendColumns(b, joinTable.SQLAlias, joinTable.Fields)
+		b = appendColumns(b, joinTable.SQLAlias, q.db.selectableFields(joinTable.Fields))
 	}
 
-	q = q.ColumnExpr(internal.String(b))
+	if len(b) > 0 {
+		q = q.ColumnExpr(internal.String(b))
+	}
 
 	return q
 }
@@ -0,0 +1,184 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// MigrationPlan describes a migration that would be applied or rolled back
+// together with the queries that the migration would execute.
+type MigrationPlan struct {
+	Migration Migration
+	Queries   []string
+}
+
+// PlanRollback returns the migrations that Rollback would roll back
+// without executing them. See PlanDown for how the queries are collected.
+func (m *Migrator) PlanRollback(ctx context.Context) ([]MigrationPlan, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	migrations, err := m.MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.planDown(ctx, migrations.LastGroup().Migrations)
+}
+
+// PlanDown returns the last n applied migrations in the order they would be
+// rolled back without executing them. Down functions are run against a DB that
+// records queries instead of sending them to the database, so queries that
+// read data return no rows.
+func (m *Migrator) PlanDown(ctx context.Context, n int) ([]MigrationPlan, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("migrate: number of migrations must be positive: %d", n)
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	migrations, err := m.MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := migrations.Applied()
+	if len(applied) > n {
+		applied = applied[:n]
+	}
+	return m.planDown(ctx, applied)
+}
+
+func (m *Migrator) planDown(ctx context.Context, migrations MigrationSlice) ([]MigrationPlan, error) {
+	plans := make([]MigrationPlan, 0, len(migrations))
+
+	for i := range migrations {
+		migration := &migrations[i]
+
+		plan := MigrationPlan{
+			Migration: *migration,
+		}
+		if migration.Down != nil {
+			queries, err := dryRun(ctx, m.db, migration.Down)
+			if err != nil {
+				return nil, err
+			}
+			plan.Queries = queries
+		}
+
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+func dryRun(ctx context.Context, db *bun.DB, fn MigrationFunc) ([]string, error) {
+	connector := new(dryRunConnector)
+
+	sqldb := sql.OpenDB(connector)
+	defer sqldb.Close()
+
+	if err := fn(ctx, bun.NewDB(sqldb, dryRunDialect{db.Dialect()})); err != nil {
+		return nil, err
+	}
+	return connector.queries, nil
+}
+
+// dryRunDialect does not let the dialect inspect the dry-run database.
+type dryRunDialect struct {
+	schema.Dialect
+}
+
+func (dryRunDialect) Init(*sql.DB) {}
+
+//------------------------------------------------------------------------------
+
+var errDryRunPrepare = errors.New("migrate: prepared statements are not supported in dry-run mode")
+
+type dryRunConnector struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+var _ driver.Connector = (*dryRunConnector)(nil)
+
+func (c *dryRunConnector) Connect(context.Context) (driver.Conn, error) {
+	return &dryRunConn{c: c}, nil
+}
+
+func (c *dryRunConnector) Driver() driver.Driver {
+	return dryRunDriver{}
+}
+
+func (c *dryRunConnector) record(query string) {
+	c.mu.Lock()
+	c.queries = append(c.queries, query)
+	c.mu.Unlock()
+}
+
+type dryRunDriver struct{}
+
+func (dryRunDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("migrate: dry-run driver can't be opened by name")
+}
+
+type dryRunConn struct {
+	c *dryRunConnector
+}
+
+var (
+	_ driver.ExecerContext  = (*dryRunConn)(nil)
+	_ driver.QueryerContext = (*dryRunConn)(nil)
+	_ driver.ConnBeginTx    = (*dryRunConn)(nil)
+)
+
+func (cn *dryRunConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errDryRunPrepare
+}
+
+func (cn *dryRunConn) Close() error {
+	return nil
+}
+
+func (cn *dryRunConn) Begin() (driver.Tx, error) {
+	return dryRunTx{}, nil
+}
+
+func (cn *dryRunConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return dryRunTx{}, nil
+}
+
+func (cn *dryRunConn) ExecContext(
+	ctx context.Context, query string, args []driver.NamedValue,
+) (driver.Result, error) {
+	cn.c.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+func (cn *dryRunConn) QueryContext(
+	ctx context.Context, query string, args []driver.NamedValue,
+) (driver.Rows, error) {
+	cn.c.record(query)
+	return dryRunRows{}, nil
+}
+
+type dryRunTx struct{}
+
+func (dryRunTx) Commit() error   { return nil }
+func (dryRunTx) Rollback() error { return nil }
+
+type dryRunRows struct{}
+
+func (dryRunRows) Columns() []string         { return nil }
+func (dryRunRows) Close() error              { return nil }
+func (dryRunRows) Next([]driver.Value) error { return io.EOF }
@@ -0,0 +1,44 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+type dryRunModel struct {
+	ID   int64
+	Name string
+}
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+	db := bun.NewDB(nil, schema.NewNopDialect())
+
+	queries, err := dryRun(ctx, db, func(ctx context.Context, db *bun.DB) error {
+		var models []dryRunModel
+		if err := db.NewSelect().Model(&models).Where("name = ?", "foo").Scan(ctx); err != nil {
+			return err
+		}
+		require.Len(t, models, 0)
+
+		if _, err := db.NewDropTable().Model((*dryRunModel)(nil)).IfExists().Exec(ctx); err != nil {
+			return err
+		}
+
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			_, err := tx.ExecContext(ctx, "DROP INDEX ?", bun.Ident("dry_run_idx"))
+			return err
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`SELECT "dry_run_model"."id", "dry_run_model"."name" FROM "dry_run_models" AS "dry_run_model" WHERE (name = 'foo')`,
+		`DROP TABLE IF EXISTS "dry_run_models"`,
+		`DROP INDEX "dry_run_idx"`,
+	}, queries)
+}
@@ -19,8 +19,18 @@ func WithMigrationsDirectory(directory string) MigrationsOption {
 	}
 }
 
+// WithFS discovers SQL migrations in fsys, for example, in an embed.FS,
+// so migration files don't need to exist at runtime.
+// It panics if the files can't be read or have invalid names.
+func WithFS(fsys fs.FS) MigrationsOption {
+	return func(m *Migrations) {
+		m.fsys = fsys
+	}
+}
+
 type Migrations struct {
-	ms MigrationSlice
+	ms   MigrationSlice
+	fsys fs.FS
 
 	explicitDirectory string
 	implicitDirectory string
@@ -32,9 +42,31 @@ func NewMigrations(opts ...MigrationsOption) *Migrations {
 		opt(m)
 	}
 	m.implicitDirectory = filepath.Dir(migrationFile())
+	if m.fsys != nil {
+		if err := m.Discover(m.fsys); err != nil {
+			panic(err)
+		}
+	}
 	return m
 }
 
+// LoadDir returns migrations loaded from the SQL files in the directory dir
+// of fsys. Files must be named like 20060102150405_name.up.sql and
+// 20060102150405_name.down.sql. Go migrations can be added to the returned
+// migrations using Register.
+func LoadDir(fsys fs.FS, dir string) (*Migrations, error) {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	m := NewMigrations()
+	if err := m.Discover(sub); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (m *Migrations) Sorted() MigrationSlice {
 	migrations := make(MigrationSlice, len(m.ms))
 	copy(migrations, m.ms)
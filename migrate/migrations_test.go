@@ -0,0 +1,89 @@
+package migrate_test
+
+import (
+	"embed"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uptrace/bun/migrate"
+)
+
+func TestLoadDir(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/20210101000000_create_users.up.sql":   {Data: []byte("CREATE TABLE users ()")},
+		"migrations/20210101000000_create_users.down.sql": {Data: []byte("DROP TABLE users")},
+		"migrations/20210102000000_add_index.tx.up.sql":   {Data: []byte("CREATE INDEX users_idx ON users ()")},
+		"migrations/20210103000000_drop-column.down.sql":  {Data: []byte("SELECT 1")},
+		"migrations/README.md":                            {Data: []byte("not a migration")},
+		"other/20210104000000_other.up.sql":               {Data: []byte("SELECT 1")},
+	}
+
+	migrations, err := migrate.LoadDir(fsys, "migrations")
+	require.NoError(t, err)
+
+	ms := migrations.Sorted()
+	require.Len(t, ms, 3)
+
+	require.Equal(t, "20210101000000", ms[0].Name)
+	require.NotNil(t, ms[0].Up)
+	require.NotNil(t, ms[0].Down)
+
+	require.Equal(t, "20210102000000", ms[1].Name)
+	require.NotNil(t, ms[1].Up)
+	require.Nil(t, ms[1].Down)
+
+	require.Equal(t, "20210103000000", ms[2].Name)
+	require.Nil(t, ms[2].Up)
+	require.NotNil(t, ms[2].Down)
+}
+
+func TestLoadDirInvalidName(t *testing.T) {
+	for _, fname := range []string{
+		"2021_create_users.up.sql",
+		"20210101000000_Create_Users.up.sql",
+		"create_users.down.sql",
+	} {
+		fsys := fstest.MapFS{
+			"migrations/" + fname: {Data: []byte("SELECT 1")},
+		}
+		_, err := migrate.LoadDir(fsys, "migrations")
+		require.Error(t, err, fname)
+		require.Contains(t, err.Error(), "unsupported migration name format", fname)
+	}
+}
+
+func TestLoadDirMissing(t *testing.T) {
+	_, err := migrate.LoadDir(fstest.MapFS{}, "migrations")
+	require.Error(t, err)
+}
+
+//go:embed testdata/migrations
+var embedMigrations embed.FS
+
+func TestWithFS(t *testing.T) {
+	migrations := migrate.NewMigrations(migrate.WithFS(embedMigrations))
+
+	ms := migrations.Sorted()
+	require.Len(t, ms, 2)
+	require.Equal(t, "20210101000000", ms[0].Name)
+	require.NotNil(t, ms[0].Up)
+	require.NotNil(t, ms[0].Down)
+	require.Equal(t, "20210102000000", ms[1].Name)
+	require.NotNil(t, ms[1].Up)
+	require.Nil(t, ms[1].Down)
+
+	migrations = migrate.NewMigrations(migrate.WithFS(fstest.MapFS{
+		"20210101000000_first.up.sql": {Data: []byte("SELECT 1")},
+	}))
+	require.Len(t, migrations.Sorted(), 1)
+}
+
+func TestWithFSInvalidName(t *testing.T) {
+	require.PanicsWithError(t, `migrate: unsupported migration name format: "first.up.sql"`, func() {
+		migrate.NewMigrations(migrate.WithFS(fstest.MapFS{
+			"first.up.sql": {Data: []byte("SELECT 1")},
+		}))
+	})
+}
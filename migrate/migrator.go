@@ -27,6 +27,23 @@ func WithLocksTableName(table string) MigratorOption {
 	}
 }
 
+// BeforeMigrate returns an option that calls the fn before each migration
+// is applied by Migrate. If the fn returns an error, Migrate stops and returns it.
+// Hooks are not called for migrations marked as applied with WithNopMigration.
+func BeforeMigrate(fn func(ctx context.Context, db *bun.DB, name string) error) MigratorOption {
+	return func(m *Migrator) {
+		m.beforeMigrate = append(m.beforeMigrate, fn)
+	}
+}
+
+// AfterMigrate returns an option that calls the fn after each migration
+// applied by Migrate with the error returned by the migration, if any.
+func AfterMigrate(fn func(ctx context.Context, db *bun.DB, name string, err error)) MigratorOption {
+	return func(m *Migrator) {
+		m.afterMigrate = append(m.afterMigrate, fn)
+	}
+}
+
 type Migrator struct {
 	db         *bun.DB
 	migrations *Migrations
@@ -35,6 +52,9 @@ type Migrator struct {
 
 	table      string
 	locksTable string
+
+	beforeMigrate []func(ctx context.Context, db *bun.DB, name string) error
+	afterMigrate  []func(ctx context.Context, db *bun.DB, name string, err error)
 }
 
 func NewMigrator(db *bun.DB, migrations *Migrations, opts ...MigratorOption) *Migrator {
@@ -124,13 +144,19 @@ func (m *Migrator) Migrate(ctx context.Context, opts ...MigrationOption) (*Migra
 		migration := &group.Migrations[i]
 		migration.GroupID = group.ID
 
-		if !cfg.nop && migration.Up != nil {
-			if err := migration.Up(ctx, m.db); err != nil {
+		if cfg.nop {
+			if err := m.MarkApplied(ctx, migration); err != nil {
 				return nil, err
 			}
+			continue
 		}
 
-		if err := m.MarkApplied(ctx, migration); err != nil {
+		if err := m.runBeforeMigrate(ctx, migration); err != nil {
+			return nil, err
+		}
+		err := m.up(ctx, migration)
+		m.runAfterMigrate(ctx, migration, err)
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -138,6 +164,30 @@ func (m *Migrator) Migrate(ctx context.Context, opts ...MigrationOption) (*Migra
 	return group, nil
 }
 
+func (m *Migrator) up(ctx context.Context, migration *Migration) error {
+	if migration.Up != nil {
+		if err := migration.Up(ctx, m.db); err != nil {
+			return err
+		}
+	}
+	return m.MarkApplied(ctx, migration)
+}
+
+func (m *Migrator) runBeforeMigrate(ctx context.Context, migration *Migration) error {
+	for _, fn := range m.beforeMigrate {
+		if err := fn(ctx, m.db, migration.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) runAfterMigrate(ctx context.Context, migration *Migration, err error) {
+	for _, fn := range m.afterMigrate {
+		fn(ctx, m.db, migration.Name, err)
+	}
+}
+
 func (m *Migrator) Rollback(ctx context.Context, opts ...MigrationOption) (*MigrationGroup, error) {
 	cfg := newMigrationConfig(opts)
 
@@ -0,0 +1 @@
+DROP TABLE embed_models;
@@ -0,0 +1 @@
+CREATE TABLE embed_models (id int);
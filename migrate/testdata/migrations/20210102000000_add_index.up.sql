@@ -0,0 +1 @@
+CREATE INDEX embed_models_idx ON embed_models (id);
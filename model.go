@@ -20,6 +20,35 @@ type Model interface {
 	Value() interface{}
 }
 
+type scanProgressKey struct{}
+
+// scanProgress reports the number of rows scanned by the slice models,
+// see SelectQuery.ScanWithProgress.
+type scanProgress struct {
+	interval int64
+	fn       func(n int64)
+	n        int64
+}
+
+func withScanProgress(ctx context.Context, p *scanProgress) context.Context {
+	return context.WithValue(ctx, scanProgressKey{}, p)
+}
+
+func scanProgressFromContext(ctx context.Context) *scanProgress {
+	p, _ := ctx.Value(scanProgressKey{}).(*scanProgress)
+	return p
+}
+
+func (p *scanProgress) row() {
+	if p == nil {
+		return
+	}
+	p.n++
+	if p.n%p.interval == 0 {
+		p.fn(p.n)
+	}
+}
+
 type rowScanner interface {
 	ScanRow(ctx context.Context, rows *sql.Rows) error
 }
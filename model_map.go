@@ -66,7 +66,18 @@ func (m *mapModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error) {
 	return 1, nil
 }
 
+var rawBytesType = reflect.TypeOf((*sql.RawBytes)(nil)).Elem()
+
 func (m *mapModel) Scan(src interface{}) error {
+	// Keep the type of sql.RawBytes values that were put into the map by the caller.
+	if _, ok := m.m[m.columns[m.scanIndex]].(sql.RawBytes); ok {
+		var b sql.RawBytes
+		if err := schema.Scanner(rawBytesType)(reflect.ValueOf(&b).Elem(), src); err != nil {
+			return err
+		}
+		return m.scanRaw(b)
+	}
+
 	if _, ok := src.([]byte); !ok {
 		return m.scanRaw(src)
 	}
@@ -56,6 +56,7 @@ func (m *mapSliceModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, erro
 		slice = slice[:0]
 	}
 
+	progress := scanProgressFromContext(ctx)
 	var n int
 
 	for rows.Next() {
@@ -68,6 +69,7 @@ func (m *mapSliceModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, erro
 
 		slice = append(slice, m.m)
 		n++
+		progress.row()
 	}
 	if err := rows.Err(); err != nil {
 		return 0, err
@@ -0,0 +1,102 @@
+package bun
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// ModelRow provides access to the fields of a model without knowing its type.
+// Fields are identified by column names, e.g. user_id.
+type ModelRow struct {
+	table *schema.Table
+	strct reflect.Value
+}
+
+// ModelSlice returns the rows of a slice of structs or struct pointers,
+// for example, []User, []*User, or a pointer to such a slice.
+// Nil struct pointers are skipped.
+func ModelSlice(slice interface{}) ([]ModelRow, error) {
+	v := reflect.ValueOf(slice)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("bun: ModelSlice(nil %T)", slice)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("bun: ModelSlice(unsupported %T)", slice)
+	}
+
+	elemType := indirectType(v.Type().Elem())
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bun: ModelSlice(unsupported %T)", slice)
+	}
+	table := nopDB.Table(elemType)
+
+	rows := make([]ModelRow, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		rows = append(rows, ModelRow{
+			table: table,
+			strct: elem,
+		})
+	}
+	return rows, nil
+}
+
+// Table returns the table of the model.
+func (r ModelRow) Table() *schema.Table {
+	return r.table
+}
+
+// PK returns the primary key value. For composite primary keys it returns
+// a []interface{} with the values in the order of declaration and for tables
+// without a primary key it returns nil.
+func (r ModelRow) PK() interface{} {
+	switch len(r.table.PKs) {
+	case 0:
+		return nil
+	case 1:
+		return r.table.PKs[0].Value(r.strct).Interface()
+	}
+
+	values := make([]interface{}, len(r.table.PKs))
+	for i, pk := range r.table.PKs {
+		values[i] = pk.Value(r.strct).Interface()
+	}
+	return values
+}
+
+// Get returns the value of the field with the column name.
+func (r ModelRow) Get(column string) (interface{}, bool) {
+	field, ok := r.table.FieldMap[column]
+	if !ok {
+		return nil, false
+	}
+	return field.Value(r.strct).Interface(), true
+}
+
+// Set sets the value of the field with the column name. Values that are not
+// assignable to the field are converted like values scanned from the database.
+func (r ModelRow) Set(column string, value interface{}) error {
+	field, err := r.table.Field(column)
+	if err != nil {
+		return err
+	}
+
+	if value != nil {
+		if v := reflect.ValueOf(value); v.Type().AssignableTo(field.StructField.Type) {
+			field.Value(r.strct).Set(v)
+			return nil
+		}
+	}
+	return field.ScanValue(r.strct, value)
+}
@@ -57,6 +57,7 @@ func (m *sliceModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error)
 	}
 	dest := makeDest(m, len(columns))
 
+	progress := scanProgressFromContext(ctx)
 	var n int
 
 	for rows.Next() {
@@ -65,6 +66,7 @@ func (m *sliceModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error)
 			return 0, err
 		}
 		n++
+		progress.row()
 	}
 	if err := rows.Err(); err != nil {
 		return 0, err
@@ -47,6 +47,7 @@ func (m *hasManyModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error
 		return 0, err
 	}
 
+	m.ctx = ctx
 	m.columns = columns
 	dest := makeDest(m, len(columns))
 
@@ -88,7 +89,7 @@ func (m *hasManyModel) Scan(src interface{}) error {
 		return err
 	}
 
-	if err := field.ScanValue(m.strct, src); err != nil {
+	if err := field.ScanValueContext(m.ctx, m.strct, src); err != nil {
 		return err
 	}
 
@@ -47,6 +47,7 @@ func (m *m2mModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error) {
 		return 0, err
 	}
 
+	m.ctx = ctx
 	m.columns = columns
 	dest := makeDest(m, len(columns))
 
@@ -88,7 +89,7 @@ func (m *m2mModel) Scan(src interface{}) error {
 		return m.scanM2MColumn(column, src)
 	}
 
-	if err := field.ScanValue(m.strct, src); err != nil {
+	if err := field.ScanValueContext(m.ctx, m.strct, src); err != nil {
 		return err
 	}
 
@@ -75,6 +75,7 @@ func (m *sliceTableModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, er
 		m.slice.Set(m.slice.Slice(0, 0))
 	}
 
+	progress := scanProgressFromContext(ctx)
 	var n int
 
 	for rows.Next() {
@@ -86,6 +87,7 @@ func (m *sliceTableModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, er
 		}
 
 		n++
+		progress.row()
 	}
 	if err := rows.Err(); err != nil {
 		return 0, err
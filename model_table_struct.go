@@ -27,6 +27,9 @@ type structTableModel struct {
 
 	columns   []string
 	scanIndex int
+
+	// ctx is the context of the query that is being scanned.
+	ctx context.Context
 }
 
 var _ tableModel = (*structTableModel)(nil)
@@ -128,16 +131,23 @@ func (m *structTableModel) BeforeScan(ctx context.Context) error {
 var _ schema.AfterScanHook = (*structTableModel)(nil)
 
 func (m *structTableModel) AfterScan(ctx context.Context) error {
-	if !m.table.HasAfterScanHook() || !m.structInited {
+	if !m.structInited {
 		return nil
 	}
 
+	if m.table.HasComputedFields() {
+		m.table.ComputeFields(m.strct)
+	}
+
 	var firstErr error
 
-	if err := callAfterScanHook(ctx, m.strct.Addr()); err != nil && firstErr == nil {
-		firstErr = err
+	if m.table.HasAfterScanHook() {
+		if err := callAfterScanHook(ctx, m.strct.Addr()); err != nil {
+			firstErr = err
+		}
 	}
 
+	// Joined models may have hooks and computed fields even if the model doesn't.
 	for _, j := range m.joins {
 		switch j.Relation.Type {
 		case schema.HasOneRelation, schema.BelongsToRelation:
@@ -258,6 +268,7 @@ func (m *structTableModel) scanRow(ctx context.Context, rows *sql.Rows, dest []i
 		return err
 	}
 
+	m.ctx = ctx
 	m.scanIndex = 0
 	if err := rows.Scan(dest...); err != nil {
 		return err
@@ -288,28 +299,66 @@ func (m *structTableModel) ScanColumn(column string, src interface{}) error {
 }
 
 func (m *structTableModel) scanColumn(column string, src interface{}) (bool, error) {
-	if src != nil {
+	switch {
+	case src != nil:
+		if err := m.initStruct(); err != nil {
+			return true, err
+		}
+	case m.strct.Kind() == reflect.Ptr:
+		// The join model is nil when the LEFT JOIN doesn't match a row.
+		if m.strct.IsNil() {
+			return true, nil
+		}
 		if err := m.initStruct(); err != nil {
 			return true, err
 		}
 	}
 
-	if field, ok := m.table.FieldMap[column]; ok {
-		return true, field.ScanValue(m.strct, src)
-	}
+	joinName, joinColumn := splitColumn(column)
 
-	if joinName, column := splitColumn(column); joinName != "" {
+	// Joined columns are scanned by the join model rather than by the inlined
+	// relation fields so the join model runs its hooks and computes its fields.
+	if joinName != "" {
 		if join := m.GetJoin(joinName); join != nil {
-			return true, join.JoinModel.ScanColumn(column, src)
+			if jm, ok := join.JoinModel.(*structTableModel); ok {
+				jm.ctx = m.ctx
+			}
+			return true, join.JoinModel.ScanColumn(joinColumn, src)
 		}
-		if m.table.ModelName == joinName {
-			return true, m.ScanColumn(column, src)
+	}
+
+	if field, ok := m.table.FieldMap[column]; ok {
+		if src == nil && m.db.flags.Has(nullPolicyError) && !isNullableField(field) {
+			return true, fmt.Errorf("bun: %s.%s can't be scanned from NULL (use a pointer or sql.Null* type)",
+				m.table.TypeName, field.GoName)
 		}
+		return true, field.ScanValueContext(m.ctx, m.strct, src)
+	}
+
+	if joinName != "" && m.table.ModelName == joinName {
+		return true, m.ScanColumn(joinColumn, src)
 	}
 
 	return false, nil
 }
 
+var (
+	sqlScannerType     = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	contextScannerType = reflect.TypeOf((*schema.ContextScanner)(nil)).Elem()
+)
+
+func isNullableField(field *schema.Field) bool {
+	if field.NullZero || field.StructField.Type.Kind() == reflect.Ptr {
+		return true
+	}
+	switch field.IndirectType.Kind() {
+	case reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+	ptr := reflect.PtrTo(field.IndirectType)
+	return ptr.Implements(sqlScannerType) || ptr.Implements(contextScannerType)
+}
+
 // sqlite3 sometimes does not unquote columns.
 func unquote(s string) string {
 	if s == "" {
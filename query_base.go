@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/internal"
@@ -19,8 +20,9 @@ const (
 )
 
 type withQuery struct {
-	name  string
-	query schema.QueryAppender
+	name      string
+	query     schema.QueryAppender
+	recursive bool
 }
 
 // IConn is a common interface for *sql.DB, *sql.Conn, and *sql.Tx.
@@ -55,6 +57,8 @@ type IDB interface {
 	NewTruncateTable() *TruncateTableQuery
 	NewAddColumn() *AddColumnQuery
 	NewDropColumn() *DropColumnQuery
+	NewCreateSchema(name string) *CreateSchemaQuery
+	NewDropSchema(name string) *DropSchemaQuery
 }
 
 var (
@@ -63,6 +67,20 @@ var (
 	_ IConn = (*Tx)(nil)
 )
 
+// QueryExec is a common interface for query builders that can return rows,
+// for example, using a RETURNING clause.
+type QueryExec interface {
+	schema.QueryAppender
+	QueryRows(ctx context.Context) (*sql.Rows, error)
+}
+
+var (
+	_ QueryExec = (*SelectQuery)(nil)
+	_ QueryExec = (*InsertQuery)(nil)
+	_ QueryExec = (*UpdateQuery)(nil)
+	_ QueryExec = (*DeleteQuery)(nil)
+)
+
 type baseQuery struct {
 	db   *DB
 	conn IConn
@@ -103,6 +121,75 @@ func (q *baseQuery) setConn(db IConn) {
 	}
 }
 
+func (q *baseQuery) getConn() IConn {
+	if db, ok := q.conn.(*sql.DB); ok && db == nil {
+		panic(noSQLDBMsg)
+	}
+	return q.conn
+}
+
+func (q *baseQuery) queryRows(
+	ctx context.Context, iquery schema.QueryAppender,
+) (*sql.Rows, string, error) {
+	queryBytes, err := iquery.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := internal.String(queryBytes)
+
+	if _, ok := TenantSchema(ctx); ok {
+		if _, ok := q.conn.(*sql.Tx); !ok {
+			return nil, query, errTenantRows
+		}
+	}
+	// The release func is a no-op for transactions.
+	conn, _, err := q.tenantConn(ctx)
+	if err != nil {
+		return nil, query, err
+	}
+
+	rows, err := q.queryContext(ctx, conn, iquery, query)
+	return rows, query, err
+}
+
+// queryContext executes the query and retries SELECT queries
+// on the fallback replica when the primary can't be reached.
+func (q *baseQuery) queryContext(
+	ctx context.Context, conn IConn, queryApp schema.QueryAppender, query string,
+) (*sql.Rows, error) {
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil && q.canFallback(conn, queryApp, err) {
+		replica := q.db.fallbackReplica
+		ctx, event := replica.beforeQuery(ctx, queryApp, query, nil)
+		rows, err = replica.DB.QueryContext(ctx, query)
+		replica.afterQuery(ctx, event, nil, err)
+	}
+	if err != nil {
+		return nil, newQueryError(err, queryApp, query)
+	}
+	return rows, nil
+}
+
+// fallbackScanRow executes the query on the fallback replica
+// calling the replica query hooks and scans the row into dest.
+func (q *baseQuery) fallbackScanRow(
+	ctx context.Context, queryApp schema.QueryAppender, query string, dest ...interface{},
+) error {
+	replica := q.db.fallbackReplica
+	ctx, event := replica.beforeQuery(ctx, queryApp, query, nil)
+	err := replica.DB.QueryRowContext(ctx, query).Scan(dest...)
+	replica.afterQuery(ctx, event, nil, err)
+	return err
+}
+
+func (q *baseQuery) canFallback(conn IConn, queryApp schema.QueryAppender, err error) bool {
+	if q.db.fallbackReplica == nil || conn != q.db.DB {
+		return false
+	}
+	return isSelectQuery(queryApp) && isConnError(err)
+}
+
 // TODO: rename to setModel
 func (q *baseQuery) setTableModel(modeli interface{}) {
 	model, err := newSingleModel(q.db, modeli)
@@ -185,12 +272,30 @@ func (q *baseQuery) addWith(name string, query schema.QueryAppender) {
 	})
 }
 
+func (q *baseQuery) addWithRecursive(name string, query schema.QueryAppender) {
+	if !q.db.features.Has(feature.WithRecursive) {
+		q.setErr(fmt.Errorf("bun: %s does not support WITH RECURSIVE", q.db.dialect.Name()))
+		return
+	}
+	q.with = append(q.with, withQuery{
+		name:      name,
+		query:     query,
+		recursive: true,
+	})
+}
+
 func (q *baseQuery) appendWith(fmter schema.Formatter, b []byte) (_ []byte, err error) {
 	if len(q.with) == 0 {
 		return b, nil
 	}
 
 	b = append(b, "WITH "...)
+	for _, with := range q.with {
+		if with.recursive {
+			b = append(b, "RECURSIVE "...)
+			break
+		}
+	}
 	for i, with := range q.with {
 		if i > 0 {
 			b = append(b, ", "...)
@@ -283,10 +388,17 @@ func (q *baseQuery) appendTablesWithAlias(
 
 func (q *baseQuery) _appendTables(
 	fmter schema.Formatter, b []byte, withAlias bool,
+) (_ []byte, err error) {
+	return q.appendTableList(fmter, b, q.modelHasTableName(), q.tables, withAlias)
+}
+
+// appendTableList appends the model table if withModel is true followed by the tables.
+func (q *baseQuery) appendTableList(
+	fmter schema.Formatter, b []byte, withModel bool, tables []schema.QueryWithArgs, withAlias bool,
 ) (_ []byte, err error) {
 	startLen := len(b)
 
-	if q.modelHasTableName() {
+	if withModel {
 		if !q.modelTable.IsZero() {
 			b, err = q.modelTable.AppendQuery(fmter, b)
 			if err != nil {
@@ -301,7 +413,7 @@ func (q *baseQuery) _appendTables(
 		}
 	}
 
-	for _, table := range q.tables {
+	for _, table := range tables {
 		if len(b) > startLen {
 			b = append(b, ", "...)
 		}
@@ -438,7 +550,14 @@ func (q *baseQuery) scan(
 ) (res result, _ error) {
 	ctx, event := q.db.beforeQuery(ctx, queryApp, query, nil)
 
-	rows, err := q.conn.QueryContext(ctx, query)
+	conn, release, err := q.tenantConn(ctx)
+	if err != nil {
+		q.db.afterQuery(ctx, event, nil, err)
+		return res, err
+	}
+	defer release()
+
+	rows, err := q.queryContext(ctx, conn, queryApp, query)
 	if err != nil {
 		q.db.afterQuery(ctx, event, nil, err)
 		return res, err
@@ -468,11 +587,19 @@ func (q *baseQuery) exec(
 ) (res result, _ error) {
 	ctx, event := q.db.beforeQuery(ctx, queryApp, query, nil)
 
-	r, err := q.conn.ExecContext(ctx, query)
+	conn, release, err := q.tenantConn(ctx)
 	if err != nil {
 		q.db.afterQuery(ctx, event, nil, err)
 		return res, err
 	}
+	defer release()
+
+	r, err := conn.ExecContext(ctx, query)
+	if err != nil {
+		err = newQueryError(err, queryApp, query)
+		q.db.afterQuery(ctx, event, nil, err)
+		return res, err
+	}
 
 	res.r = r
 
@@ -501,10 +628,10 @@ func (q *baseQuery) AppendNamedArg(fmter schema.Formatter, b []byte, name string
 		b = appendColumns(b, q.table.SQLAlias, q.table.PKs)
 		return b, true
 	case "Columns":
-		b = appendColumns(b, "", q.table.Fields)
+		b = appendColumns(b, "", q.db.selectableFields(q.table.Fields))
 		return b, true
 	case "TableColumns":
-		b = appendColumns(b, q.table.SQLAlias, q.table.Fields)
+		b = appendColumns(b, q.table.SQLAlias, q.db.selectableFields(q.table.Fields))
 		return b, true
 	}
 
@@ -626,7 +753,11 @@ func appendWhere(
 		}
 
 		b = append(b, '(')
-		b, err = where.AppendQuery(fmter, b)
+		if exists, ok := existsArg(where.QueryWithArgs); ok {
+			b, err = exists.AppendQuery(fmter, b)
+		} else {
+			b, err = where.AppendQuery(fmter, b)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -635,6 +766,33 @@ func appendWhere(
 	return b, nil
 }
 
+// existsQuery is an EXISTS condition. appendWhere appends it directly instead
+// of using the formatter, so errors of the subquery are returned to the caller.
+type existsQuery struct {
+	not   bool
+	query schema.QueryAppender
+}
+
+func (e existsQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if e.not {
+		b = append(b, "NOT "...)
+	}
+	b = append(b, "EXISTS ("...)
+	b, err = e.query.AppendQuery(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, ')'), nil
+}
+
+func existsArg(q schema.QueryWithArgs) (existsQuery, bool) {
+	if q.Query != "?" || len(q.Args) != 1 {
+		return existsQuery{}, false
+	}
+	exists, ok := q.Args[0].(existsQuery)
+	return exists, ok
+}
+
 func (q *whereBaseQuery) appendWherePK(
 	fmter schema.Formatter, b []byte, withAlias bool,
 ) (_ []byte, err error) {
@@ -688,22 +846,29 @@ func (q *whereBaseQuery) appendWherePKStruct(
 func (q *whereBaseQuery) appendWherePKSlice(
 	fmter schema.Formatter, b []byte, model *sliceTableModel, withAlias bool,
 ) (_ []byte, err error) {
-	if len(q.table.PKs) > 1 {
-		b = append(b, '(')
-	}
+	var alias schema.Safe
 	if withAlias {
-		b = appendColumns(b, q.table.SQLAlias, q.table.PKs)
-	} else {
-		b = appendColumns(b, "", q.table.PKs)
+		alias = q.table.SQLAlias
 	}
-	if len(q.table.PKs) > 1 {
+	return appendPKsIn(fmter, b, q.table, alias, model.slice), nil
+}
+
+// appendPKsIn appends `(pk1, pk2) IN ((1, 2), (3, 4))` using PK values
+// of the structs in the slice.
+func appendPKsIn(
+	fmter schema.Formatter, b []byte, table *schema.Table, alias schema.Safe, slice reflect.Value,
+) []byte {
+	if len(table.PKs) > 1 {
+		b = append(b, '(')
+	}
+	b = appendColumns(b, alias, table.PKs)
+	if len(table.PKs) > 1 {
 		b = append(b, ')')
 	}
 
 	b = append(b, " IN ("...)
 
 	isTemplate := fmter.IsNop()
-	slice := model.slice
 	sliceLen := slice.Len()
 	for i := 0; i < sliceLen; i++ {
 		if i > 0 {
@@ -715,10 +880,10 @@ func (q *whereBaseQuery) appendWherePKSlice(
 
 		el := indirect(slice.Index(i))
 
-		if len(q.table.PKs) > 1 {
+		if len(table.PKs) > 1 {
 			b = append(b, '(')
 		}
-		for i, f := range q.table.PKs {
+		for i, f := range table.PKs {
 			if i > 0 {
 				b = append(b, ", "...)
 			}
@@ -728,14 +893,14 @@ func (q *whereBaseQuery) appendWherePKSlice(
 				b = f.AppendValue(fmter, b, el)
 			}
 		}
-		if len(q.table.PKs) > 1 {
+		if len(table.PKs) > 1 {
 			b = append(b, ')')
 		}
 	}
 
 	b = append(b, ')')
 
-	return b, nil
+	return b
 }
 
 //------------------------------------------------------------------------------
@@ -771,30 +936,57 @@ func (q *returningQuery) hasReturning() bool {
 	return len(q.returning) > 0 || len(q.returningFields) > 0
 }
 
+// appendReturning appends the RETURNING clause excluding the columns
+// that the roles set with WithACL don't allow.
 func (q *returningQuery) appendReturning(
-	fmter schema.Formatter, b []byte,
+	fmter schema.Formatter, b []byte, db *DB, table *schema.Table,
 ) (_ []byte, err error) {
 	if !q.hasReturning() {
 		return b, nil
 	}
 
 	b = append(b, " RETURNING "...)
+	start := len(b)
+
+	if len(q.returning) == 0 {
+		b = appendColumns(b, "", db.selectableFields(q.returningFields))
+	}
+
+	var n int
+	for _, f := range q.returning {
+		var fields []*schema.Field
+		if table != nil {
+			if db.isACLStar(f) {
+				fields = db.selectableFields(table.Fields)
+				if len(fields) == 0 {
+					continue
+				}
+			} else if field, ok := table.FieldMap[f.Query]; ok && len(f.Args) == 0 &&
+				!db.canSelect(field) {
+				continue
+			}
+		}
 
-	for i, f := range q.returning {
-		if i > 0 {
+		if n > 0 {
 			b = append(b, ", "...)
 		}
+		n++
+
+		if fields != nil {
+			b = appendColumns(b, "", fields)
+			continue
+		}
+
 		b, err = f.AppendQuery(fmter, b)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if len(q.returning) > 0 {
-		return b, nil
+	if len(b) == start {
+		return nil, fmt.Errorf("bun: WithACL roles don't allow returning any columns of %s",
+			table.TypeName)
 	}
-
-	b = appendColumns(b, "", q.returningFields)
 	return b, nil
 }
 
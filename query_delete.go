@@ -179,7 +179,7 @@ func (q *DeleteQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 	}
 
 	if len(q.returning) > 0 {
-		b, err = q.appendReturning(fmter, b)
+		b, err = q.appendReturning(fmter, b, q.db, q.table)
 		if err != nil {
 			return nil, err
 		}
@@ -194,6 +194,13 @@ func (q *DeleteQuery) isSoftDelete() bool {
 
 //------------------------------------------------------------------------------
 
+// QueryRows executes the query and returns the rows without scanning them,
+// which is useful together with a RETURNING clause. Model hooks are not called.
+func (q *DeleteQuery) QueryRows(ctx context.Context) (*sql.Rows, error) {
+	rows, _, err := q.queryRows(ctx, q)
+	return rows, err
+}
+
 func (q *DeleteQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
 	if q.table != nil {
 		if err := q.beforeDeleteHook(ctx); err != nil {
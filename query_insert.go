@@ -3,8 +3,11 @@ package bun
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/internal"
@@ -21,6 +24,9 @@ type InsertQuery struct {
 
 	ignore  bool
 	replace bool
+	refresh bool
+
+	selectQuery *SelectQuery
 }
 
 func NewInsertQuery(db *DB) *InsertQuery {
@@ -88,6 +94,15 @@ func (q *InsertQuery) ExcludeColumn(columns ...string) *InsertQuery {
 	return q
 }
 
+// Select generates an `INSERT INTO table (columns) SELECT ...` query that inserts
+// the rows selected by the query instead of the model values. The columns are
+// the ones set with Column or, if there are none, the model columns.
+// The query must select the same number of columns in the same order.
+func (q *InsertQuery) Select(query *SelectQuery) *InsertQuery {
+	q.selectQuery = query
+	return q
+}
+
 // Value overwrites model value for the column in INSERT and UPDATE queries.
 func (q *InsertQuery) Value(column string, value string, args ...interface{}) *InsertQuery {
 	if q.table == nil {
@@ -98,6 +113,32 @@ func (q *InsertQuery) Value(column string, value string, args ...interface{}) *I
 	return q
 }
 
+// SetDefault inserts the column default value using the DEFAULT keyword.
+// Dialects that don't support DEFAULT in VALUES use the default from the field
+// `default` tag or omit the column.
+func (q *InsertQuery) SetDefault(column string) *InsertQuery {
+	if q.table == nil {
+		q.setErr(errNilModel)
+		return q
+	}
+
+	field, ok := q.table.FieldMap[column]
+	if !ok {
+		q.setErr(fmt.Errorf("bun: %s does not have column=%q", q.table, column))
+		return q
+	}
+
+	switch {
+	case q.db.features.Has(feature.DefaultPlaceholder):
+		q.addValue(q.table, column, "DEFAULT", nil)
+	case field.SQLDefault != "":
+		q.addValue(q.table, column, field.SQLDefault, nil)
+	default:
+		q.excludeColumn([]string{column})
+	}
+	return q
+}
+
 func (q *InsertQuery) Where(query string, args ...interface{}) *InsertQuery {
 	q.addWhere(schema.SafeQueryWithSep(query, args, " AND "))
 	return q
@@ -125,6 +166,16 @@ func (q *InsertQuery) hasReturning() bool {
 	return q.returningQuery.hasReturning()
 }
 
+// Refresh populates the model with the inserted rows including the values
+// set by the database, for example, column defaults and triggers.
+// It uses `RETURNING *` when the database supports it and otherwise
+// selects the inserted rows by the primary key, in which case slices
+// are ordered by the primary key.
+func (q *InsertQuery) Refresh() *InsertQuery {
+	q.refresh = true
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 // Ignore generates an `INSERT IGNORE INTO` query (MySQL).
@@ -181,7 +232,7 @@ func (q *InsertQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 	}
 
 	if q.hasReturning() {
-		b, err = q.appendReturning(fmter, b)
+		b, err = q.appendReturning(fmter, b, q.db, q.table)
 		if err != nil {
 			return nil, err
 		}
@@ -193,6 +244,10 @@ func (q *InsertQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 func (q *InsertQuery) appendColumnsValues(
 	fmter schema.Formatter, b []byte,
 ) (_ []byte, err error) {
+	if q.selectQuery != nil {
+		return q.appendColumnsSelect(fmter, b)
+	}
+
 	if q.hasMultiTables() {
 		if q.columns != nil {
 			b = append(b, " ("...)
@@ -252,6 +307,29 @@ func (q *InsertQuery) appendColumnsValues(
 	return b, nil
 }
 
+func (q *InsertQuery) appendColumnsSelect(
+	fmter schema.Formatter, b []byte,
+) (_ []byte, err error) {
+	switch {
+	case q.columns != nil:
+		b = append(b, " ("...)
+		b, err = q.appendColumns(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, ")"...)
+	case q.table != nil:
+		// Zero values are not known until the SELECT runs,
+		// so all model columns are inserted.
+		b = append(b, " ("...)
+		b = appendColumns(b, "", q.table.Fields)
+		b = append(b, ")"...)
+	}
+
+	b = append(b, ' ')
+	return q.selectQuery.AppendQuery(fmter, b)
+}
+
 func (q *InsertQuery) appendStructValues(
 	fmter schema.Formatter, b []byte, fields []*schema.Field, strct reflect.Value,
 ) (_ []byte, err error) {
@@ -274,7 +352,7 @@ func (q *InsertQuery) appendStructValues(
 		switch {
 		case isTemplate:
 			b = append(b, '?')
-		case f.NullZero && f.HasZeroValue(strct):
+		case (f.NullZero || f.SQLDefault != "") && f.HasZeroValue(strct):
 			if q.db.features.Has(feature.DefaultPlaceholder) {
 				b = append(b, "DEFAULT"...)
 			} else if f.SQLDefault != "" {
@@ -385,6 +463,27 @@ func (q *InsertQuery) On(s string, args ...interface{}) *InsertQuery {
 	return q
 }
 
+// OnConflictWhere adds ON CONFLICT (target) WHERE condition DO action that targets
+// a partial unique index, for example:
+//
+//	q.OnConflictWhere("email", "deleted_at IS NULL", "UPDATE").Set("name = EXCLUDED.name")
+//
+// The target, condition, and action are formatted as a single query with the args.
+func (q *InsertQuery) OnConflictWhere(
+	target, condition, action string, args ...interface{},
+) *InsertQuery {
+	if q.db.features.Has(feature.OnDuplicateKey) {
+		q.setErr(errors.New("bun: OnConflictWhere is not supported by MySQL"))
+		return q
+	}
+	query := "CONFLICT (" + target + ") WHERE " + condition + " DO " + action
+	q.onConflict = schema.SafeQuery(query, args)
+	return q
+}
+
+// Set adds an expression to the SET clause of ON CONFLICT DO UPDATE
+// (or ON DUPLICATE KEY UPDATE in MySQL). Multiple calls are joined with commas.
+// When Set is not used, the clause sets the selected columns from EXCLUDED.
 func (q *InsertQuery) Set(query string, args ...interface{}) *InsertQuery {
 	q.addSet(schema.SafeQuery(query, args))
 	return q
@@ -448,6 +547,13 @@ func (q *InsertQuery) appendSetExcluded(b []byte, fields []*schema.Field) []byte
 
 //------------------------------------------------------------------------------
 
+// QueryRows executes the query and returns the rows without scanning them,
+// which is useful together with a RETURNING clause. Model hooks are not called.
+func (q *InsertQuery) QueryRows(ctx context.Context) (*sql.Rows, error) {
+	rows, _, err := q.queryRows(ctx, q)
+	return rows, err
+}
+
 func (q *InsertQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
 	if q.table != nil {
 		if err := q.beforeInsertHook(ctx); err != nil {
@@ -455,6 +561,15 @@ func (q *InsertQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result
 		}
 	}
 
+	if q.refresh {
+		if q.table == nil {
+			return nil, errors.New("bun: Refresh requires a struct or slice-based model")
+		}
+		if q.db.features.Has(feature.Returning) && len(q.returning) == 0 {
+			q.Returning("*")
+		}
+	}
+
 	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
 	if err != nil {
 		return nil, err
@@ -482,6 +597,12 @@ func (q *InsertQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result
 		if err := q.tryLastInsertID(res, dest); err != nil {
 			return nil, err
 		}
+
+		if q.refresh && len(dest) == 0 && !q.db.features.Has(feature.Returning) {
+			if err := q.refreshModel(ctx); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if q.table != nil {
@@ -493,6 +614,134 @@ func (q *InsertQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result
 	return res, nil
 }
 
+// GetOrCreate inserts the model unless it conflicts with an existing row and
+// reports whether the row was created. Conflicts are ignored using
+// ON CONFLICT DO NOTHING or ON DUPLICATE KEY UPDATE pk = pk (MySQL), and the conflicting
+// row is selected into dest using the primary key and the unique columns of the model.
+// Other errors, for example, NOT NULL violations, are returned as is.
+// Dest is usually the model itself; a nil dest also selects into the model.
+func (q *InsertQuery) GetOrCreate(ctx context.Context, dest interface{}) (bool, error) {
+	if q.err != nil {
+		return false, q.err
+	}
+
+	model, ok := q.tableModel.(*structTableModel)
+	if !ok {
+		return false, errors.New("bun: GetOrCreate requires a struct model")
+	}
+	if dest == nil {
+		dest = q.model
+	}
+
+	sel, err := q.getExistingQuery(model, dest)
+	if err != nil {
+		return false, err
+	}
+
+	if q.onConflict.IsZero() {
+		if q.db.features.Has(feature.OnDuplicateKey) {
+			// Unlike INSERT IGNORE, this ignores only duplicate key errors.
+			field := q.table.Fields[0]
+			if len(q.table.PKs) > 0 {
+				field = q.table.PKs[0]
+			}
+			q.onConflict = schema.SafeQuery("DUPLICATE KEY UPDATE ? = ?",
+				[]interface{}{field.SQLName, field.SQLName})
+		} else {
+			q.onConflict = schema.SafeQuery("CONFLICT DO NOTHING", nil)
+		}
+	}
+
+	if q.db.features.Has(feature.Returning) {
+		if len(q.returning) == 0 {
+			q.Returning("*")
+		}
+
+		_, err := q.Exec(ctx, dest)
+		if err == nil {
+			return true, nil
+		}
+		if err != sql.ErrNoRows {
+			return false, err
+		}
+		return false, sel.Scan(ctx)
+	}
+
+	res, err := q.Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n > 0 && dest == q.model {
+		return true, nil
+	}
+	return n > 0, sel.Scan(ctx)
+}
+
+// getExistingQuery returns a query that selects the row that conflicts with the model.
+func (q *InsertQuery) getExistingQuery(model *structTableModel, dest interface{}) (*SelectQuery, error) {
+	sel := q.db.NewSelect().Conn(q.conn).Model(dest)
+	var hasCond bool
+
+	addGroup := func(fields []*schema.Field) {
+		conds := make([]string, len(fields))
+		args := make([]interface{}, 0, 2*len(fields))
+		for i, f := range fields {
+			conds[i] = "? = ?"
+			args = append(args, Ident(f.Name), f.Value(model.strct).Interface())
+		}
+		sel.WhereOr(strings.Join(conds, " AND "), args...)
+		hasCond = true
+	}
+
+	if len(q.table.PKs) > 0 {
+		var zeroPK bool
+		for _, pk := range q.table.PKs {
+			if pk.HasZeroValue(model.strct) {
+				zeroPK = true
+				break
+			}
+		}
+		if !zeroPK {
+			addGroup(q.table.PKs)
+		}
+	}
+
+	names := make([]string, 0, len(q.table.Unique))
+	for name := range q.table.Unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		addGroup(q.table.Unique[name])
+	}
+
+	if !hasCond {
+		return nil, fmt.Errorf(
+			"bun: GetOrCreate requires a primary key or unique columns (model=%s)",
+			q.table.TypeName)
+	}
+	return sel, nil
+}
+
+// refreshModel selects the inserted rows by the primary key into the model.
+func (q *InsertQuery) refreshModel(ctx context.Context) error {
+	if err := q.table.CheckPKs(); err != nil {
+		return err
+	}
+	sel := q.db.NewSelect().Conn(q.conn).Model(q.model.Value()).WherePK()
+	if _, ok := q.model.(*sliceTableModel); ok {
+		for _, pk := range q.table.PKs {
+			sel.OrderExpr("?", pk.SQLName)
+		}
+	}
+	return sel.Scan(ctx)
+}
+
 func (q *InsertQuery) beforeInsertHook(ctx context.Context) error {
 	if hook, ok := q.table.ZeroIface.(BeforeInsertHook); ok {
 		if err := hook.BeforeInsert(ctx, q); err != nil {
@@ -0,0 +1,91 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// CreateSchemaQuery creates a PostgreSQL schema. On other dialects
+// it returns ErrDialectUnsupported.
+type CreateSchemaQuery struct {
+	baseQuery
+
+	name          string
+	ifNotExists   bool
+	authorization string
+}
+
+func NewCreateSchemaQuery(db *DB, name string) *CreateSchemaQuery {
+	q := &CreateSchemaQuery{
+		baseQuery: baseQuery{
+			db:   db,
+			conn: db.DB,
+		},
+		name: name,
+	}
+	return q
+}
+
+func (q *CreateSchemaQuery) Conn(db IConn) *CreateSchemaQuery {
+	q.setConn(db)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateSchemaQuery) IfNotExists() *CreateSchemaQuery {
+	q.ifNotExists = true
+	return q
+}
+
+// Authorization makes the role the owner of the schema.
+func (q *CreateSchemaQuery) Authorization(role string) *CreateSchemaQuery {
+	q.authorization = role
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateSchemaQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if !fmter.HasFeature(feature.Schema) {
+		return nil, ErrDialectUnsupported
+	}
+
+	b = append(b, "CREATE SCHEMA "...)
+	if q.ifNotExists {
+		b = append(b, "IF NOT EXISTS "...)
+	}
+	b = fmter.AppendIdent(b, q.name)
+
+	if q.authorization != "" {
+		b = append(b, " AUTHORIZATION "...)
+		b = fmter.AppendIdent(b, q.authorization)
+	}
+
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateSchemaQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
+	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	query := internal.String(queryBytes)
+
+	res, err := q.exec(ctx, q, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
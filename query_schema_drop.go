@@ -0,0 +1,103 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// DropSchemaQuery drops a PostgreSQL schema. On other dialects
+// it returns ErrDialectUnsupported.
+type DropSchemaQuery struct {
+	baseQuery
+
+	name     string
+	ifExists bool
+	cascade  bool
+	restrict bool
+}
+
+func NewDropSchemaQuery(db *DB, name string) *DropSchemaQuery {
+	q := &DropSchemaQuery{
+		baseQuery: baseQuery{
+			db:   db,
+			conn: db.DB,
+		},
+		name: name,
+	}
+	return q
+}
+
+func (q *DropSchemaQuery) Conn(db IConn) *DropSchemaQuery {
+	q.setConn(db)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropSchemaQuery) IfExists() *DropSchemaQuery {
+	q.ifExists = true
+	return q
+}
+
+// Cascade also drops the objects contained in the schema.
+func (q *DropSchemaQuery) Cascade() *DropSchemaQuery {
+	q.cascade = true
+	q.restrict = false
+	return q
+}
+
+// Restrict refuses to drop the schema if it contains any objects.
+// This is the default.
+func (q *DropSchemaQuery) Restrict() *DropSchemaQuery {
+	q.restrict = true
+	q.cascade = false
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropSchemaQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if !fmter.HasFeature(feature.Schema) {
+		return nil, ErrDialectUnsupported
+	}
+
+	b = append(b, "DROP SCHEMA "...)
+	if q.ifExists {
+		b = append(b, "IF EXISTS "...)
+	}
+	b = fmter.AppendIdent(b, q.name)
+
+	switch {
+	case q.cascade:
+		b = append(b, " CASCADE"...)
+	case q.restrict:
+		b = append(b, " RESTRICT"...)
+	}
+
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropSchemaQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
+	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	query := internal.String(queryBytes)
+
+	res, err := q.exec(ctx, q, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -6,11 +6,16 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/schema"
 )
@@ -26,12 +31,25 @@ type SelectQuery struct {
 	distinctOn []schema.QueryWithArgs
 	joins      []joinQuery
 	group      []schema.QueryWithArgs
-	having     []schema.QueryWithArgs
+	having     []schema.QueryWithSep
+	windows    []schema.QueryWithArgs
 	order      []schema.QueryWithArgs
 	limit      int32
 	offset     int32
 	selFor     schema.QueryWithArgs
 
+	tableSample schema.QueryWithArgs
+
+	cursorField  string
+	cursorBefore bool
+
+	progressInterval int64
+
+	lockTimeout time.Duration
+	lockWait    string
+
+	pgSettings []string
+
 	union []union
 }
 
@@ -51,6 +69,35 @@ func (q *SelectQuery) Conn(db IConn) *SelectQuery {
 	return q
 }
 
+// Clone returns a copy of the query that can be modified independently of q.
+// The DB, the connection, and the model are shared by both queries.
+func (q *SelectQuery) Clone() *SelectQuery {
+	clone := *q
+
+	clone.with = append([]withQuery(nil), q.with...)
+	clone.tables = append([]schema.QueryWithArgs(nil), q.tables...)
+	clone.columns = append([]schema.QueryWithArgs(nil), q.columns...)
+	clone.where = append([]schema.QueryWithSep(nil), q.where...)
+
+	clone.distinctOn = append([]schema.QueryWithArgs(nil), q.distinctOn...)
+	clone.group = append([]schema.QueryWithArgs(nil), q.group...)
+	clone.having = append([]schema.QueryWithSep(nil), q.having...)
+	clone.windows = append([]schema.QueryWithArgs(nil), q.windows...)
+	clone.order = append([]schema.QueryWithArgs(nil), q.order...)
+	clone.pgSettings = append([]string(nil), q.pgSettings...)
+	clone.union = append([]union(nil), q.union...)
+
+	if q.joins != nil {
+		clone.joins = make([]joinQuery, len(q.joins))
+		for i, j := range q.joins {
+			j.on = append([]schema.QueryWithSep(nil), j.on...)
+			clone.joins[i] = j
+		}
+	}
+
+	return &clone
+}
+
 func (q *SelectQuery) Model(model interface{}) *SelectQuery {
 	q.setTableModel(model)
 	return q
@@ -61,11 +108,27 @@ func (q *SelectQuery) Apply(fn func(*SelectQuery) *SelectQuery) *SelectQuery {
 	return fn(q)
 }
 
+// ApplyIf calls fn like Apply if cond is true and otherwise returns the query unchanged.
+func (q *SelectQuery) ApplyIf(cond bool, fn func(*SelectQuery) *SelectQuery) *SelectQuery {
+	if !cond {
+		return q
+	}
+	return fn(q)
+}
+
 func (q *SelectQuery) With(name string, query schema.QueryAppender) *SelectQuery {
 	q.addWith(name, query)
 	return q
 }
 
+// WithRecursive adds a recursive common table expression that can reference
+// itself, for example, to select a tree of categories. If any CTE of the query
+// is recursive, the query starts with WITH RECURSIVE.
+func (q *SelectQuery) WithRecursive(name string, query schema.QueryAppender) *SelectQuery {
+	q.addWithRecursive(name, query)
+	return q
+}
+
 func (q *SelectQuery) Distinct() *SelectQuery {
 	q.distinctOn = make([]schema.QueryWithArgs, 0)
 	return q
@@ -90,6 +153,58 @@ func (q *SelectQuery) TableExpr(query string, args ...interface{}) *SelectQuery
 	return q
 }
 
+// OnlyTable adds the table to the FROM clause using `ONLY table` so tables
+// that inherit from the table are not scanned. It is only supported by PostgreSQL.
+func (q *SelectQuery) OnlyTable(table string) *SelectQuery {
+	if !q.db.features.Has(feature.TableInherits) {
+		q.setErr(errors.New("bun: ONLY is not supported by the current dialect"))
+		return q
+	}
+	q.addTable(schema.SafeQuery("ONLY ?", []interface{}{schema.UnsafeIdent(table)}))
+	return q
+}
+
+// TableFunction adds a set-returning function to the FROM clause using
+// `ROWS FROM (fn) AS alias`, for example:
+//
+//	q.TableFunction("generate_series(?, ?)", "n", 1, 10)
+//
+// It is only supported by PostgreSQL.
+func (q *SelectQuery) TableFunction(fn, alias string, args ...interface{}) *SelectQuery {
+	return q.addTableFunction("", fn, alias, args)
+}
+
+// LateralTableFunction is like TableFunction, but adds `LATERAL ROWS FROM (fn) AS alias`
+// so the function args can reference the preceding tables.
+func (q *SelectQuery) LateralTableFunction(fn, alias string, args ...interface{}) *SelectQuery {
+	return q.addTableFunction("LATERAL ", fn, alias, args)
+}
+
+func (q *SelectQuery) addTableFunction(
+	prefix, fn, alias string, args []interface{},
+) *SelectQuery {
+	if !q.db.features.Has(feature.RowsFrom) {
+		q.setErr(errors.New("bun: ROWS FROM is not supported by the current dialect"))
+		return q
+	}
+
+	args = append(args[:len(args):len(args)], Ident(alias))
+	q.addTable(schema.SafeQuery(prefix+"ROWS FROM ("+fn+") AS ?", args))
+	return q
+}
+
+// TableSample adds `TABLESAMPLE method(percent)` to the first table of the query
+// to scan only a sample of the rows, for example, TableSample("BERNOULLI", "5").
+// The method and the percent are not escaped. It is only supported by PostgreSQL.
+func (q *SelectQuery) TableSample(method, percent string) *SelectQuery {
+	if !q.db.features.Has(feature.TableSample) {
+		q.setErr(errors.New("bun: TABLESAMPLE is not supported by the current dialect"))
+		return q
+	}
+	q.tableSample = schema.SafeQuery(method+"("+percent+")", nil)
+	return q
+}
+
 func (q *SelectQuery) ModelTableExpr(query string, args ...interface{}) *SelectQuery {
 	q.modelTable = schema.SafeQuery(query, args)
 	return q
@@ -109,6 +224,106 @@ func (q *SelectQuery) ColumnExpr(query string, args ...interface{}) *SelectQuery
 	return q
 }
 
+// ColumnJSON selects the value at the dot-separated path in the JSON column,
+// for example, ColumnJSON("data", "address.city", "city"). Numeric path elements
+// are array indexes. When the model has a string, number, or bool field for the alias,
+// the value is extracted as text. Otherwise the JSON fragment is selected and
+// is decoded into the field like other JSON fields.
+func (q *SelectQuery) ColumnJSON(jsonCol, jsonPath, alias string) *SelectQuery {
+	keys := strings.Split(jsonPath, ".")
+	text := q.isScalarField(alias)
+
+	var query strings.Builder
+	args := []interface{}{Ident(jsonCol)}
+
+	switch {
+	case q.db.features.Has(feature.JSONOperators):
+		query.WriteString("?")
+		for i, key := range keys {
+			if i == len(keys)-1 && text {
+				query.WriteString(" ->> ")
+			} else {
+				query.WriteString(" -> ")
+			}
+			if n, err := strconv.Atoi(key); err == nil {
+				query.WriteString(strconv.Itoa(n))
+			} else {
+				query.WriteString("?")
+				args = append(args, key)
+			}
+		}
+	case q.db.features.Has(feature.JSONUnquote):
+		if text {
+			query.WriteString("JSON_UNQUOTE(JSON_EXTRACT(?, ?))")
+		} else {
+			query.WriteString("JSON_EXTRACT(?, ?)")
+		}
+		args = append(args, jsonPathString(keys))
+	default:
+		query.WriteString("json_extract(?, ?)")
+		args = append(args, jsonPathString(keys))
+	}
+
+	query.WriteString(" AS ?")
+	args = append(args, Ident(alias))
+
+	q.addColumn(schema.SafeQuery(query.String(), args))
+	return q
+}
+
+func (q *SelectQuery) isScalarField(column string) bool {
+	if q.table == nil {
+		return false
+	}
+	field, ok := q.table.FieldMap[column]
+	if !ok {
+		return false
+	}
+	switch field.IndirectType.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// jsonPathString returns a MySQL and SQLite JSON path like $.address.city or $.tags[0].
+func jsonPathString(keys []string) string {
+	b := []byte{'$'}
+	for _, key := range keys {
+		if _, err := strconv.Atoi(key); err == nil {
+			b = append(b, '[')
+			b = append(b, key...)
+			b = append(b, ']')
+			continue
+		}
+		b = append(b, '.')
+		if isJSONPathIdent(key) {
+			b = append(b, key...)
+		} else {
+			b = strconv.AppendQuote(b, key)
+		}
+	}
+	return string(b)
+}
+
+func isJSONPathIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 func (q *SelectQuery) ExcludeColumn(columns ...string) *SelectQuery {
 	q.excludeColumn(columns)
 	return q
@@ -131,6 +346,189 @@ func (q *SelectQuery) WhereOr(query string, args ...interface{}) *SelectQuery {
 	return q
 }
 
+// WhereBetween adds `column BETWEEN lo AND hi` condition.
+// Values are appended using the dialect, for example, time.Time is formatted
+// as a timestamp literal.
+func (q *SelectQuery) WhereBetween(column string, lo, hi interface{}) *SelectQuery {
+	q.addWhere(schema.SafeQueryWithSep(
+		"? BETWEEN ? AND ?", []interface{}{Ident(column), lo, hi}, " AND "))
+	return q
+}
+
+// WhereNotBetween adds `column NOT BETWEEN lo AND hi` condition.
+func (q *SelectQuery) WhereNotBetween(column string, lo, hi interface{}) *SelectQuery {
+	q.addWhere(schema.SafeQueryWithSep(
+		"? NOT BETWEEN ? AND ?", []interface{}{Ident(column), lo, hi}, " AND "))
+	return q
+}
+
+// WhereNull adds `column IS NULL` condition.
+func (q *SelectQuery) WhereNull(column string) *SelectQuery {
+	return q.Where("? IS NULL", Ident(column))
+}
+
+// WhereNotNull adds `column IS NOT NULL` condition.
+func (q *SelectQuery) WhereNotNull(column string) *SelectQuery {
+	return q.Where("? IS NOT NULL", Ident(column))
+}
+
+// WhereOrNull is like WhereNull, but joins the condition using OR.
+func (q *SelectQuery) WhereOrNull(column string) *SelectQuery {
+	return q.WhereOr("? IS NULL", Ident(column))
+}
+
+// WhereOrNotNull is like WhereNotNull, but joins the condition using OR.
+func (q *SelectQuery) WhereOrNotNull(column string) *SelectQuery {
+	return q.WhereOr("? IS NOT NULL", Ident(column))
+}
+
+// WhereLike adds `column LIKE pattern` condition.
+func (q *SelectQuery) WhereLike(column, pattern string) *SelectQuery {
+	return q.Where("? LIKE ?", Ident(column), pattern)
+}
+
+// WhereNotLike adds `column NOT LIKE pattern` condition.
+func (q *SelectQuery) WhereNotLike(column, pattern string) *SelectQuery {
+	return q.Where("? NOT LIKE ?", Ident(column), pattern)
+}
+
+// WhereILike adds case-insensitive `column ILIKE pattern` condition.
+// Dialects without ILIKE use `LOWER(column) LIKE LOWER(pattern)` instead.
+func (q *SelectQuery) WhereILike(column, pattern string) *SelectQuery {
+	if q.db.features.Has(feature.ILike) {
+		return q.Where("? ILIKE ?", Ident(column), pattern)
+	}
+	return q.Where("LOWER(?) LIKE LOWER(?)", Ident(column), pattern)
+}
+
+// WhereNotILike is like WhereILike, but adds NOT ILIKE condition.
+func (q *SelectQuery) WhereNotILike(column, pattern string) *SelectQuery {
+	if q.db.features.Has(feature.ILike) {
+		return q.Where("? NOT ILIKE ?", Ident(column), pattern)
+	}
+	return q.Where("LOWER(?) NOT LIKE LOWER(?)", Ident(column), pattern)
+}
+
+// WhereIn adds `column IN (values)` condition, where values is a slice or an array.
+// A single value adds `column = value` condition instead and no values add
+// a condition that is always false, because `IN ()` is not valid SQL.
+func (q *SelectQuery) WhereIn(column string, values interface{}) *SelectQuery {
+	v := reflect.Indirect(reflect.ValueOf(values))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		q.setErr(fmt.Errorf("bun: WhereIn(unsupported %T)", values))
+		return q
+	}
+	switch v.Len() {
+	case 0:
+		return q.Where("1 = 0")
+	case 1:
+		return q.Where("? = ?", Ident(column), v.Index(0).Interface())
+	}
+	return q.Where("? IN (?)", Ident(column), In(v.Interface()))
+}
+
+// WhereFilters adds a `column = value` condition for each entry of filters,
+// for example, filters built from URL query params. The keys must be column
+// names of the model; unknown keys are rejected with an error, so the filters
+// can't be used to inject SQL or to query arbitrary columns. Nil values
+// are compared using IS NULL.
+func (q *SelectQuery) WhereFilters(filters map[string]interface{}) *SelectQuery {
+	if q.table == nil {
+		q.setErr(errors.New("bun: WhereFilters requires a model"))
+		return q
+	}
+
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		field, err := q.table.Field(key)
+		if err != nil {
+			q.setErr(err)
+			return q
+		}
+
+		value := filters[key]
+		if value == nil {
+			q.Where("?.? IS NULL", q.table.SQLAlias, field.SQLName)
+		} else {
+			q.Where("?.? = ?", q.table.SQLAlias, field.SQLName, value)
+		}
+	}
+	return q
+}
+
+// WhereExists adds `EXISTS (subquery)` to the WHERE conditions.
+// Errors of the subquery are returned by the query.
+func (q *SelectQuery) WhereExists(subquery schema.QueryAppender) *SelectQuery {
+	q.addWhere(schema.SafeQueryWithSep("?", []interface{}{existsQuery{query: subquery}}, " AND "))
+	return q
+}
+
+// WhereNotExists adds `NOT EXISTS (subquery)` to the WHERE conditions.
+func (q *SelectQuery) WhereNotExists(subquery schema.QueryAppender) *SelectQuery {
+	q.addWhere(schema.SafeQueryWithSep(
+		"?", []interface{}{existsQuery{not: true, query: subquery}}, " AND "))
+	return q
+}
+
+// WhereRegexp adds a condition that the column matches the regular expression,
+// using `~` on PostgreSQL, which is case-sensitive, and REGEXP on MySQL,
+// which is case-sensitive only for binary strings and case-sensitive collations.
+// SQLite is not supported, because its REGEXP operator requires a user-defined function.
+func (q *SelectQuery) WhereRegexp(column, pattern string) *SelectQuery {
+	return q.whereRegexp(column, pattern, false)
+}
+
+// WhereNotRegexp is like WhereRegexp, but adds a condition that the column
+// does not match the regular expression.
+func (q *SelectQuery) WhereNotRegexp(column, pattern string) *SelectQuery {
+	return q.whereRegexp(column, pattern, true)
+}
+
+func (q *SelectQuery) whereRegexp(column, pattern string, not bool) *SelectQuery {
+	var query string
+	switch {
+	case q.db.features.Has(feature.PosixRegexp):
+		query = "? ~ ?"
+		if not {
+			query = "? !~ ?"
+		}
+	case q.db.features.Has(feature.Regexp):
+		query = "? REGEXP ?"
+		if not {
+			query = "? NOT REGEXP ?"
+		}
+	default:
+		q.setErr(fmt.Errorf("bun: %s does not support regular expressions", q.db.dialect.Name()))
+		return q
+	}
+	return q.Where(query, Ident(column), pattern)
+}
+
+// WhereIRegexp is like WhereRegexp, but matches case-insensitively using `~*`
+// on PostgreSQL and REGEXP_LIKE on MySQL 8.
+func (q *SelectQuery) WhereIRegexp(column, pattern string) *SelectQuery {
+	var query string
+	switch {
+	case q.db.features.Has(feature.PosixRegexp):
+		query = "? ~* ?"
+	case q.db.features.Has(feature.RegexpLike):
+		query = "REGEXP_LIKE(?, ?, 'i')"
+	case q.db.features.Has(feature.Regexp):
+		q.setErr(fmt.Errorf("bun: %s does not support case-insensitive regular expressions",
+			q.db.dialect.Name()))
+		return q
+	default:
+		q.setErr(fmt.Errorf("bun: %s does not support regular expressions", q.db.dialect.Name()))
+		return q
+	}
+	return q.Where(query, Ident(column), pattern)
+}
+
 func (q *SelectQuery) WhereGroup(sep string, fn func(*SelectQuery) *SelectQuery) *SelectQuery {
 	saved := q.where
 	q.where = nil
@@ -164,13 +562,96 @@ func (q *SelectQuery) Group(columns ...string) *SelectQuery {
 	return q
 }
 
+// Groups is the same as Group and adds a GROUP BY column for each argument.
+func (q *SelectQuery) Groups(columns ...string) *SelectQuery {
+	return q.Group(columns...)
+}
+
 func (q *SelectQuery) GroupExpr(group string, args ...interface{}) *SelectQuery {
 	q.group = append(q.group, schema.SafeQuery(group, args))
 	return q
 }
 
+// GroupExprs adds a GROUP BY expression for each argument.
+func (q *SelectQuery) GroupExprs(groups ...string) *SelectQuery {
+	for _, group := range groups {
+		q.group = append(q.group, schema.SafeQuery(group, nil))
+	}
+	return q
+}
+
 func (q *SelectQuery) Having(having string, args ...interface{}) *SelectQuery {
-	q.having = append(q.having, schema.SafeQuery(having, args))
+	q.having = append(q.having, schema.SafeQueryWithSep(having, args, " AND "))
+	return q
+}
+
+func (q *SelectQuery) HavingOr(having string, args ...interface{}) *SelectQuery {
+	q.having = append(q.having, schema.SafeQueryWithSep(having, args, " OR "))
+	return q
+}
+
+// HavingGroup wraps the HAVING conditions added by fn in parentheses and
+// joins the group with the preceding conditions using sep, just like WhereGroup.
+func (q *SelectQuery) HavingGroup(sep string, fn func(*SelectQuery) *SelectQuery) *SelectQuery {
+	saved := q.having
+	q.having = nil
+
+	q = fn(q)
+
+	having := q.having
+	q.having = saved
+
+	if len(having) == 0 {
+		return q
+	}
+
+	having[0].Sep = ""
+
+	q.having = append(q.having, schema.SafeQueryWithSep("", nil, sep+"("))
+	q.having = append(q.having, having...)
+	q.having = append(q.having, schema.SafeQueryWithSep("", nil, ")"))
+
+	return q
+}
+
+// ResetWhere removes the WHERE conditions added so far including WherePK,
+// for example, to replace the conditions added by a function passed to Apply.
+func (q *SelectQuery) ResetWhere() *SelectQuery {
+	q.where = nil
+	q.flags = q.flags.Remove(wherePKFlag)
+	return q
+}
+
+// ResetGroup removes the GROUP BY expressions added so far.
+func (q *SelectQuery) ResetGroup() *SelectQuery {
+	q.group = nil
+	return q
+}
+
+// ResetHaving removes the HAVING conditions added so far.
+func (q *SelectQuery) ResetHaving() *SelectQuery {
+	q.having = nil
+	return q
+}
+
+// ResetOrder removes the ORDER BY expressions added so far.
+func (q *SelectQuery) ResetOrder() *SelectQuery {
+	q.order = nil
+	return q
+}
+
+// Window adds a named window definition to the WINDOW clause, for example:
+//
+//	q.ColumnExpr("rank() OVER w").Window("w", "PARTITION BY ? ORDER BY salary DESC", bun.Ident("dept"))
+//
+// MySQL 5 does not support window functions.
+func (q *SelectQuery) Window(name, definition string, args ...interface{}) *SelectQuery {
+	if !q.db.features.Has(feature.Window) {
+		q.setErr(errors.New("bun: WINDOW is not supported by the current dialect"))
+		return q
+	}
+	query := string(q.db.fmter.AppendIdent(nil, name)) + " AS (" + definition + ")"
+	q.windows = append(q.windows, schema.SafeQuery(query, args))
 	return q
 }
 
@@ -208,6 +689,55 @@ func (q *SelectQuery) OrderExpr(query string, args ...interface{}) *SelectQuery
 	return q
 }
 
+// OrderRandom orders rows randomly using RANDOM() or RAND() depending on the dialect.
+func (q *SelectQuery) OrderRandom() *SelectQuery {
+	if q.db.features.Has(feature.Rand) {
+		q.order = append(q.order, schema.SafeQuery("RAND()", nil))
+	} else {
+		q.order = append(q.order, schema.SafeQuery("RANDOM()", nil))
+	}
+	return q
+}
+
+// OrderByField orders rows by the position of the column value in the values
+// using FIELD(column, values...) on MySQL and an equivalent CASE expression
+// on other dialects. Rows with other values sort first, like with FIELD.
+func (q *SelectQuery) OrderByField(column string, values ...interface{}) *SelectQuery {
+	if len(values) == 0 {
+		q.setErr(errors.New("bun: OrderByField requires at least one value"))
+		return q
+	}
+
+	args := make([]interface{}, 0, 2*len(values)+1)
+	var b strings.Builder
+
+	if q.db.features.Has(feature.FieldFunc) {
+		b.WriteString("FIELD(?")
+		args = append(args, Ident(column))
+		for _, value := range values {
+			b.WriteString(", ?")
+			args = append(args, value)
+		}
+		b.WriteString(")")
+	} else {
+		b.WriteString("CASE")
+		for i, value := range values {
+			b.WriteString(" WHEN ? = ? THEN ")
+			b.WriteString(strconv.Itoa(i + 1))
+			args = append(args, Ident(column), value)
+		}
+		b.WriteString(" ELSE 0 END")
+	}
+
+	q.order = append(q.order, schema.SafeQuery(b.String(), args))
+	return q
+}
+
+// Sample selects n random rows.
+func (q *SelectQuery) Sample(n int) *SelectQuery {
+	return q.OrderRandom().Limit(n)
+}
+
 func (q *SelectQuery) Limit(n int) *SelectQuery {
 	q.limit = int32(n)
 	return q
@@ -218,13 +748,442 @@ func (q *SelectQuery) Offset(n int) *SelectQuery {
 	return q
 }
 
+var errInvalidPage = errors.New("bun: Page requires page >= 1 and perPage >= 1")
+
+// Page sets LIMIT and OFFSET to select the page of perPage rows.
+// Pages are numbered from 1.
+func (q *SelectQuery) Page(page, perPage int) *SelectQuery {
+	if page < 1 || perPage < 1 {
+		q.setErr(errInvalidPage)
+		return q
+	}
+	q.limit = int32(perPage)
+	q.offset = int32((page - 1) * perPage)
+	return q
+}
+
+// AfterCursor selects rows where the column is greater than the cursor value
+// and, unless the query is already ordered, orders them by the column.
+// An explicit ORDER BY must start with the column in ascending order.
+// Use Cursor after Scan to get the value for the next page.
+func (q *SelectQuery) AfterCursor(column string, value interface{}) *SelectQuery {
+	return q.setCursor(column, value, false)
+}
+
+// BeforeCursor selects rows where the column is less than the cursor value
+// and, unless the query is already ordered, orders them by the column in
+// descending order, so LIMIT selects the rows closest to the cursor.
+// An explicit ORDER BY must start with the column in descending order.
+func (q *SelectQuery) BeforeCursor(column string, value interface{}) *SelectQuery {
+	return q.setCursor(column, value, true)
+}
+
+func (q *SelectQuery) setCursor(column string, value interface{}, before bool) *SelectQuery {
+	if q.cursorField != "" {
+		q.setErr(errors.New("bun: AfterCursor and BeforeCursor can't be used together"))
+		return q
+	}
+	q.cursorField = column
+	q.cursorBefore = before
+
+	op := ">"
+	if before {
+		op = "<"
+	}
+	q.addWhere(schema.SafeQueryWithSep("? "+op+" ?", []interface{}{Ident(column), value}, " AND "))
+	return q
+}
+
+// Cursor returns the cursor column values of the last and the first scanned
+// rows that can be passed to AfterCursor and BeforeCursor to select
+// the next and the previous pages. Both values are nil if no rows were scanned.
+// It requires a query model and a column set with AfterCursor or BeforeCursor.
+func (q *SelectQuery) Cursor() (next interface{}, prev interface{}, err error) {
+	if q.cursorField == "" {
+		return nil, nil, errors.New("bun: Cursor requires AfterCursor or BeforeCursor")
+	}
+	if q.table == nil {
+		return nil, nil, errors.New("bun: Cursor requires a model")
+	}
+
+	column := q.cursorField
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		column = column[i+1:]
+	}
+	field, err := q.table.Field(column)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var first, last reflect.Value
+	switch model := q.tableModel.(type) {
+	case *sliceTableModel:
+		n := model.slice.Len()
+		if n == 0 {
+			return nil, nil, nil
+		}
+		first = reflect.Indirect(model.slice.Index(0))
+		last = reflect.Indirect(model.slice.Index(n - 1))
+	case *structTableModel:
+		first = model.strct
+		last = model.strct
+	default:
+		return nil, nil, fmt.Errorf("bun: Cursor does not support %T", q.tableModel)
+	}
+	if !first.IsValid() || !last.IsValid() {
+		return nil, nil, nil
+	}
+
+	next = field.Value(last).Interface()
+	prev = field.Value(first).Interface()
+	if q.cursorBefore {
+		next, prev = prev, next
+	}
+	return next, prev, nil
+}
+
+// For sets the locking clause, for example, For("UPDATE"). It replaces
+// the clause set earlier including NOWAIT or SKIP LOCKED added with NoWait or SkipLocked.
 func (q *SelectQuery) For(s string, args ...interface{}) *SelectQuery {
 	q.selFor = schema.SafeQuery(s, args)
+	q.lockWait = ""
 	return q
 }
 
+// LockType is a row-level lock strength used by SelectQuery.Lock.
+// It can be combined with either LockNoWait or LockSkipLocked, for example,
+// `LockForUpdate | LockSkipLocked`.
+type LockType int
+
+const (
+	LockForUpdate LockType = iota + 1
+	LockForNoKeyUpdate
+	LockForShare
+	LockForKeyShare
+)
+
+const (
+	LockNoWait     LockType = 1 << 8
+	LockSkipLocked LockType = 1 << 9
+
+	lockStrengthMask LockType = LockNoWait - 1
+)
+
+func (t LockType) String() string {
+	switch t & lockStrengthMask {
+	case LockForUpdate:
+		return "UPDATE"
+	case LockForNoKeyUpdate:
+		return "NO KEY UPDATE"
+	case LockForShare:
+		return "SHARE"
+	case LockForKeyShare:
+		return "KEY SHARE"
+	default:
+		return ""
+	}
+}
+
+// Lock adds a `FOR lock OF tables` clause to lock the selected rows.
+// If tables are not specified, rows from all tables are locked.
+func (q *SelectQuery) Lock(lockType LockType, tables ...string) *SelectQuery {
+	strength := lockType.String()
+	if strength == "" {
+		q.setErr(fmt.Errorf("bun: invalid lock type: %d", lockType))
+		return q
+	}
+	if lockType&LockNoWait != 0 && lockType&LockSkipLocked != 0 {
+		q.setErr(errors.New("bun: LockNoWait and LockSkipLocked can't be combined"))
+		return q
+	}
+
+	name := q.db.dialect.Name()
+	if !q.db.features.Has(feature.RowLock) {
+		q.setErr(fmt.Errorf("bun: %s does not support row-level locking", name))
+		return q
+	}
+	switch lockType & lockStrengthMask {
+	case LockForShare:
+		if !q.db.features.Has(feature.LockForShare) {
+			q.setErr(fmt.Errorf("bun: %s does not support FOR %s", name, strength))
+			return q
+		}
+	case LockForNoKeyUpdate, LockForKeyShare:
+		if !q.db.features.Has(feature.LockKey) {
+			q.setErr(fmt.Errorf("bun: %s does not support FOR %s", name, strength))
+			return q
+		}
+	}
+	if len(tables) > 0 && !q.db.features.Has(feature.LockOf) {
+		q.setErr(fmt.Errorf("bun: %s does not support FOR %s OF tables", name, strength))
+		return q
+	}
+	if lockType&(LockNoWait|LockSkipLocked) != 0 && !q.db.features.Has(feature.LockNoWait) {
+		q.setErr(fmt.Errorf("bun: %s does not support NOWAIT and SKIP LOCKED", name))
+		return q
+	}
+
+	b := []byte(strength)
+	args := make([]interface{}, 0, len(tables))
+	for i, table := range tables {
+		if i == 0 {
+			b = append(b, " OF "...)
+		} else {
+			b = append(b, ", "...)
+		}
+		b = append(b, '?')
+		args = append(args, schema.UnsafeIdent(table))
+	}
+	switch {
+	case lockType&LockNoWait != 0:
+		b = append(b, " NOWAIT"...)
+	case lockType&LockSkipLocked != 0:
+		b = append(b, " SKIP LOCKED"...)
+	}
+
+	q.selFor = schema.SafeQuery(string(b), args)
+	q.lockWait = ""
+	return q
+}
+
+// ForShare adds a FOR SHARE clause that locks the selected rows against
+// concurrent updates. It is a shortcut for Lock(LockForShare).
+func (q *SelectQuery) ForShare() *SelectQuery {
+	return q.Lock(LockForShare)
+}
+
+// ForKeyShare adds a FOR KEY SHARE clause that only blocks changes to the
+// keys of the selected rows. It is supported only by PostgreSQL.
+func (q *SelectQuery) ForKeyShare() *SelectQuery {
+	return q.Lock(LockForKeyShare)
+}
+
+// SkipLocked adds SKIP LOCKED to the FOR clause set with For or Lock,
+// so rows that are locked by other transactions are skipped.
+func (q *SelectQuery) SkipLocked() *SelectQuery {
+	return q.setLockWait("SkipLocked", " SKIP LOCKED")
+}
+
+// NoWait adds NOWAIT to the FOR clause set with For or Lock,
+// so the query fails instead of waiting for rows locked by other transactions.
+func (q *SelectQuery) NoWait() *SelectQuery {
+	return q.setLockWait("NoWait", " NOWAIT")
+}
+
+func (q *SelectQuery) setLockWait(method, modifier string) *SelectQuery {
+	if !q.db.features.Has(feature.LockNoWait) {
+		q.setErr(fmt.Errorf("bun: %s does not support %s",
+			q.db.dialect.Name(), strings.TrimSpace(modifier)))
+		return q
+	}
+	if q.selFor.IsZero() {
+		q.setErr(fmt.Errorf("bun: %s requires a FOR clause", method))
+		return q
+	}
+	if hasLockWait(q.selFor.Query) {
+		q.setErr(fmt.Errorf("bun: %s: the FOR clause already has NOWAIT or SKIP LOCKED", method))
+		return q
+	}
+	q.lockWait = modifier
+	return q
+}
+
+func hasLockWait(s string) bool {
+	return strings.HasSuffix(s, " NOWAIT") || strings.HasSuffix(s, " SKIP LOCKED")
+}
+
+// LockTimeout limits how long the query waits for the row locks acquired by
+// the FOR clause set with For or Lock, or by FOR UPDATE if the query has no FOR clause.
+// On PostgreSQL it runs SET LOCAL lock_timeout before the query, so the query must
+// run in a transaction. On MySQL it sets innodb_lock_wait_timeout for the session
+// and restores the previous value after the query, so the query must run
+// in a transaction or on a dedicated connection.
+// Zero duration means that the query fails immediately using NOWAIT.
+func (q *SelectQuery) LockTimeout(d time.Duration) *SelectQuery {
+	if d < 0 {
+		q.setErr(fmt.Errorf("bun: negative lock timeout: %s", d))
+		return q
+	}
+
+	if !q.db.features.Has(feature.RowLock) {
+		q.setErr(fmt.Errorf("bun: %s does not support lock timeouts", q.db.dialect.Name()))
+		return q
+	}
+	// Without NOWAIT, use the minimal MySQL timeout of 1 second.
+	if !q.db.features.Has(feature.LockNoWait) && d < time.Second {
+		d = time.Second
+	}
+
+	if q.selFor.IsZero() {
+		q.Lock(LockForUpdate)
+	}
+
+	if d == 0 {
+		if !hasLockWait(q.selFor.Query) && q.lockWait == "" {
+			q.lockWait = " NOWAIT"
+		}
+		return q
+	}
+
+	q.lockTimeout = d
+	return q
+}
+
+// PGParallelWorkers sets max_parallel_workers_per_gather for the query using
+// SET LOCAL, so the query must run in a transaction. It is a no-op on
+// databases other than PostgreSQL.
+func (q *SelectQuery) PGParallelWorkers(n int) *SelectQuery {
+	if n < 0 {
+		q.setErr(fmt.Errorf("bun: negative number of parallel workers: %d", n))
+		return q
+	}
+	return q.setPGSetting("max_parallel_workers_per_gather", strconv.Itoa(n))
+}
+
+var pgMemSizeRE = regexp.MustCompile(`^\d+\s*(?:[kMGT]B)?$`)
+
+// PGWorkMem sets work_mem for the query using SET LOCAL, for example,
+// PGWorkMem("64MB"), so the query must run in a transaction. It is a no-op
+// on databases other than PostgreSQL.
+func (q *SelectQuery) PGWorkMem(size string) *SelectQuery {
+	if !pgMemSizeRE.MatchString(size) {
+		q.setErr(fmt.Errorf("bun: invalid work_mem size: %q", size))
+		return q
+	}
+	return q.setPGSetting("work_mem", "'"+size+"'")
+}
+
+func (q *SelectQuery) setPGSetting(name, value string) *SelectQuery {
+	if !q.db.features.Has(feature.SetLocal) {
+		return q
+	}
+	q.pgSettings = append(q.pgSettings, "SET LOCAL "+name+" = "+value)
+	return q
+}
+
+// setLocalSettings executes the SET statements required by LockTimeout,
+// PGParallelWorkers, and PGWorkMem on the query connection. Settings that
+// outlive the transaction are restored by the returned function,
+// which must be called after the query. The function is never nil.
+func (q *SelectQuery) setLocalSettings(ctx context.Context) (func() error, error) {
+	restore := func() error { return nil }
+	if q.err != nil || (q.lockTimeout == 0 && len(q.pgSettings) == 0) {
+		return restore, nil
+	}
+	// SET LOCAL outside of a transaction has no effect.
+	_, inTx := q.conn.(*sql.Tx)
+	if q.lockTimeout != 0 && !inTx {
+		if q.db.features.Has(feature.SetLocal) {
+			return nil, errors.New("bun: LockTimeout requires a transaction")
+		}
+		if db, ok := q.conn.(*sql.DB); ok && db != nil {
+			return nil, errors.New("bun: LockTimeout requires a transaction or a connection")
+		}
+	}
+	if len(q.pgSettings) > 0 && !inTx {
+		return nil, errors.New("bun: PGParallelWorkers and PGWorkMem require a transaction")
+	}
+
+	conn := q.getConn()
+
+	var queries []string
+	if q.lockTimeout != 0 {
+		switch q.db.dialect.Name() {
+		case dialect.PG:
+			ms := (q.lockTimeout + time.Millisecond - 1) / time.Millisecond
+			queries = append(queries,
+				"SET LOCAL lock_timeout = '"+strconv.FormatInt(int64(ms), 10)+"ms'")
+		case dialect.MySQL5, dialect.MySQL8:
+			// MySQL has no transaction-scoped variables, so the session value
+			// is restored to keep it from leaking to the pooled connection.
+			var prev int64
+			if err := conn.QueryRowContext(
+				ctx, "SELECT @@SESSION.innodb_lock_wait_timeout",
+			).Scan(&prev); err != nil {
+				return nil, err
+			}
+
+			sec := (q.lockTimeout + time.Second - 1) / time.Second
+			queries = append(queries,
+				"SET SESSION innodb_lock_wait_timeout = "+strconv.FormatInt(int64(sec), 10))
+
+			restore = func() error {
+				// The previous value must be restored even if ctx is canceled.
+				_, err := conn.ExecContext(context.Background(),
+					"SET SESSION innodb_lock_wait_timeout = "+strconv.FormatInt(prev, 10))
+				return err
+			}
+		}
+	}
+	queries = append(queries, q.pgSettings...)
+
+	for _, query := range queries {
+		if _, err := conn.ExecContext(ctx, query); err != nil {
+			_ = restore()
+			return nil, err
+		}
+	}
+	return restore, nil
+}
+
+// restoreSettings calls the function returned by setLocalSettings
+// and returns the first of err and the restore error.
+func restoreSettings(restore func() error, err error) error {
+	if restoreErr := restore(); err == nil {
+		return restoreErr
+	}
+	return err
+}
+
+// LockOrdered orders the selected rows by the column so that concurrent
+// transactions acquire row locks in the same order, which avoids deadlocks.
+// The column becomes the first ORDER BY key, before the existing order.
+// Unless a locking clause is already set, it also adds FOR UPDATE.
+func (q *SelectQuery) LockOrdered(column string) *SelectQuery {
+	if q.table != nil && !isIndexedColumn(q.table, column) {
+		internal.Warn.Printf("%s.%s is not indexed and locking rows in its order can be slow",
+			q.table.TypeName, column)
+	}
+
+	order := q.order
+	q.order = nil
+	q.Order(column)
+	q.order = append(q.order, order...)
+
+	if q.selFor.IsZero() {
+		q.Lock(LockForUpdate)
+	}
+	return q
+}
+
+func isIndexedColumn(table *schema.Table, column string) bool {
+	if i := strings.IndexByte(column, '.'); i >= 0 {
+		column = column[i+1:]
+	}
+	if i := strings.IndexByte(column, ' '); i >= 0 {
+		column = column[:i]
+	}
+
+	field, ok := table.FieldMap[column]
+	if !ok {
+		return false
+	}
+	if field.IsPK {
+		return len(table.PKs) == 1 || table.PKs[0] == field
+	}
+	for _, fields := range table.Unique {
+		if fields[0] == field {
+			return true
+		}
+	}
+	return false
+}
+
 //------------------------------------------------------------------------------
 
+// Union combines the results of the queries. Set operations added with Union,
+// UnionAll, Intersect, IntersectAll, Except, and ExceptAll are applied
+// in the order they were added regardless of the SQL operator precedence.
 func (q *SelectQuery) Union(other *SelectQuery) *SelectQuery {
 	return q.addUnion(" UNION ", other)
 }
@@ -266,6 +1225,23 @@ func (q *SelectQuery) Join(join string, args ...interface{}) *SelectQuery {
 	return q
 }
 
+// JoinLateral adds `JOIN LATERAL (subquery) AS alias ON TRUE`. The subquery
+// can reference columns of the preceding tables. Use JoinOn to replace
+// the default TRUE condition.
+func (q *SelectQuery) JoinLateral(subquery schema.QueryAppender, alias string) *SelectQuery {
+	if !q.db.features.Has(feature.Lateral) {
+		q.setErr(fmt.Errorf("bun: %s does not support LATERAL joins", q.db.dialect.Name()))
+		return q
+	}
+	q.joins = append(q.joins, joinQuery{
+		join:     schema.SafeQuery("JOIN LATERAL", nil),
+		lateral:  true,
+		subquery: subquery,
+		alias:    alias,
+	})
+	return q
+}
+
 func (q *SelectQuery) JoinOn(cond string, args ...interface{}) *SelectQuery {
 	return q.joinOn(cond, args, " AND ")
 }
@@ -276,7 +1252,7 @@ func (q *SelectQuery) JoinOnOr(cond string, args ...interface{}) *SelectQuery {
 
 func (q *SelectQuery) joinOn(cond string, args []interface{}, sep string) *SelectQuery {
 	if len(q.joins) == 0 {
-		q.err = errors.New("bun: query has no joins")
+		q.setErr(errors.New("bun: query has no joins"))
 		return q
 	}
 	j := &q.joins[len(q.joins)-1]
@@ -356,22 +1332,41 @@ func (q *SelectQuery) selectJoins(ctx context.Context, joins []join) error {
 //------------------------------------------------------------------------------
 
 func (q *SelectQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
-	return q.appendQuery(formatterWithModel(fmter, q), b, false)
+	return q.appendQuery(formatterWithModel(fmter, q), b, selectRows)
 }
 
+// selectMode controls what appendQuery selects.
+type selectMode int
+
+const (
+	selectRows selectMode = iota
+	// selectUnionOperand is like selectRows, but omits ORDER BY
+	// unless the query has LIMIT or OFFSET.
+	selectUnionOperand
+	selectCount
+	selectExists
+)
+
 func (q *SelectQuery) appendQuery(
-	fmter schema.Formatter, b []byte, count bool,
+	fmter schema.Formatter, b []byte, mode selectMode,
 ) (_ []byte, err error) {
 	if q.err != nil {
 		return nil, q.err
 	}
 
-	cteCount := count && (len(q.group) > 0 || q.distinctOn != nil)
+	// Count and Exists don't need the order, limit, offset, and locking.
+	count := mode == selectCount || mode == selectExists
+	cteCount := mode == selectCount && (len(q.group) > 0 || q.distinctOn != nil)
 	if cteCount {
 		b = append(b, "WITH _count_wrapper AS ("...)
 	}
 
 	if len(q.union) > 0 {
+		for i := range q.union {
+			if q.unionNeedsGroup(i) {
+				b = append(b, '(')
+			}
+		}
 		b = append(b, '(')
 	}
 
@@ -398,9 +1393,12 @@ func (q *SelectQuery) appendQuery(
 		b = append(b, "DISTINCT "...)
 	}
 
-	if count && !cteCount {
+	switch {
+	case mode == selectExists:
+		b = append(b, '1')
+	case mode == selectCount && !cteCount:
 		b = append(b, "count(*)"...)
-	} else {
+	default:
 		b, err = q.appendColumns(fmter, b)
 		if err != nil {
 			return nil, err
@@ -449,23 +1447,34 @@ func (q *SelectQuery) appendQuery(
 
 	if len(q.having) > 0 {
 		b = append(b, " HAVING "...)
-		for i, f := range q.having {
+		b, err = appendWhere(fmter, b, q.having)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The window definitions are only used by the columns that count(*) and 1 replace.
+	if len(q.windows) > 0 && (!count || cteCount) {
+		b = append(b, " WINDOW "...)
+		for i, w := range q.windows {
 			if i > 0 {
-				b = append(b, " AND "...)
+				b = append(b, ", "...)
 			}
-			b = append(b, '(')
-			b, err = f.AppendQuery(fmter, b)
+			b, err = w.AppendQuery(fmter, b)
 			if err != nil {
 				return nil, err
 			}
-			b = append(b, ')')
 		}
 	}
 
 	if !count {
-		b, err = q.appendOrder(fmter, b)
-		if err != nil {
-			return nil, err
+		// ORDER BY in a non-final union operand only makes sense together with LIMIT.
+		omitOrder := len(q.union) > 0 || mode == selectUnionOperand
+		if !omitOrder || q.limit != 0 || q.offset != 0 {
+			b, err = q.appendOrder(fmter, b)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		if q.limit != 0 {
@@ -484,16 +1493,24 @@ func (q *SelectQuery) appendQuery(
 			if err != nil {
 				return nil, err
 			}
+			b = append(b, q.lockWait...)
 		}
 	}
 
 	if len(q.union) > 0 {
 		b = append(b, ')')
 
-		for _, u := range q.union {
+		for i, u := range q.union {
+			if q.unionNeedsGroup(i) {
+				b = append(b, ')')
+			}
 			b = append(b, u.expr...)
 			b = append(b, '(')
-			b, err = u.query.AppendQuery(fmter, b)
+			if i < len(q.union)-1 {
+				b, err = u.query.appendUnionOperand(fmter, b)
+			} else {
+				b, err = u.query.AppendQuery(fmter, b)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -505,28 +1522,72 @@ func (q *SelectQuery) appendQuery(
 		b = append(b, ") SELECT count(*) FROM _count_wrapper"...)
 	}
 
+	if mode == selectExists {
+		b = append(b, " LIMIT 1"...)
+	}
+
 	return b, nil
 }
 
+// unionNeedsGroup reports whether the operands preceding the i-th set operation
+// must be parenthesized. INTERSECT binds tighter than UNION and EXCEPT,
+// so without grouping it would be applied before the preceding operations
+// instead of in the order the operations were added.
+func (q *SelectQuery) unionNeedsGroup(i int) bool {
+	return i > 0 && q.union[i].isIntersect() && !q.union[i-1].isIntersect()
+}
+
+func (u union) isIntersect() bool {
+	return strings.HasPrefix(u.expr, " INTERSECT")
+}
+
+// appendUnionOperand appends the query as a non-final union operand
+// omitting ORDER BY unless the query has LIMIT or OFFSET.
+func (q *SelectQuery) appendUnionOperand(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	return q.appendQuery(formatterWithModel(fmter, q), b, selectUnionOperand)
+}
+
 func (q *SelectQuery) appendColumns(fmter schema.Formatter, b []byte) (_ []byte, err error) {
-	start := len(b)
+	begin := len(b)
+	start := begin
 
 	switch {
 	case q.columns != nil:
-		for i, col := range q.columns {
-			if i > 0 {
-				b = append(b, ", "...)
+		var n int
+		for _, col := range q.columns {
+			var field *schema.Field
+			if col.Args == nil && q.table != nil {
+				field = q.table.FieldMap[col.Query]
+			}
+			if field != nil && !q.db.canSelect(field) {
+				continue
 			}
 
-			if col.Args == nil {
-				if field, ok := q.table.FieldMap[col.Query]; ok {
-					b = append(b, q.table.SQLAlias...)
-					b = append(b, '.')
-					b = append(b, field.SQLName...)
+			var fields []*schema.Field
+			if q.table != nil && q.db.isACLStar(col) {
+				fields = q.db.selectableFields(q.table.Fields)
+				if len(fields) == 0 {
 					continue
 				}
 			}
 
+			if n > 0 {
+				b = append(b, ", "...)
+			}
+			n++
+
+			if fields != nil {
+				b = appendColumns(b, q.table.SQLAlias, fields)
+				continue
+			}
+
+			if field != nil {
+				b = append(b, q.table.SQLAlias...)
+				b = append(b, '.')
+				b = append(b, field.SQLName...)
+				continue
+			}
+
 			b, err = col.AppendQuery(fmter, b)
 			if err != nil {
 				return nil, err
@@ -538,7 +1599,7 @@ func (q *SelectQuery) appendColumns(fmter schema.Formatter, b []byte) (_ []byte,
 			b = append(b, '.')
 			b = dialect.AppendString(b, fmt.Sprintf("%d columns", len(q.table.Fields)))
 		} else {
-			b = appendColumns(b, q.table.SQLAlias, q.table.Fields)
+			b = appendColumns(b, q.table.SQLAlias, q.db.selectableFields(q.table.Fields))
 		}
 	default:
 		b = append(b, '*')
@@ -562,6 +1623,11 @@ func (q *SelectQuery) appendColumns(fmter schema.Formatter, b []byte) (_ []byte,
 
 	b = bytes.TrimSuffix(b, []byte(", "))
 
+	if len(b) == begin && q.table != nil && q.db.aclRoles != nil {
+		return nil, fmt.Errorf("bun: WithACL roles don't allow selecting any columns of %s",
+			q.table.TypeName)
+	}
+
 	return b, nil
 }
 
@@ -571,20 +1637,28 @@ func (q *SelectQuery) appendHasOneColumns(
 	join.applyQuery(q)
 
 	if join.columns != nil {
-		for i, col := range join.columns {
-			if i > 0 {
-				b = append(b, ", "...)
+		var n int
+		for _, col := range join.columns {
+			var field *schema.Field
+			if col.Args == nil {
+				field = join.JoinModel.Table().FieldMap[col.Query]
+			}
+			if field != nil && !q.db.canSelect(field) {
+				continue
 			}
 
-			if col.Args == nil {
-				if field, ok := q.table.FieldMap[col.Query]; ok {
-					b = join.appendAlias(fmter, b)
-					b = append(b, '.')
-					b = append(b, field.SQLName...)
-					b = append(b, " AS "...)
-					b = join.appendAliasColumn(fmter, b, field.Name)
-					continue
-				}
+			if n > 0 {
+				b = append(b, ", "...)
+			}
+			n++
+
+			if field != nil {
+				b = join.appendAlias(fmter, b)
+				b = append(b, '.')
+				b = append(b, field.SQLName...)
+				b = append(b, " AS "...)
+				b = join.appendAliasColumn(fmter, b, field.Name)
+				continue
 			}
 
 			b, err = col.AppendQuery(fmter, b)
@@ -595,7 +1669,7 @@ func (q *SelectQuery) appendHasOneColumns(
 		return b, nil
 	}
 
-	for i, field := range join.JoinModel.Table().Fields {
+	for i, field := range q.db.selectableFields(join.JoinModel.Table().Fields) {
 		if i > 0 {
 			b = append(b, ", "...)
 		}
@@ -610,10 +1684,47 @@ func (q *SelectQuery) appendHasOneColumns(
 
 func (q *SelectQuery) appendTables(fmter schema.Formatter, b []byte) (_ []byte, err error) {
 	b = append(b, " FROM "...)
-	return q.appendTablesWithAlias(fmter, b)
+	if q.tableSample.IsZero() {
+		return q.appendTablesWithAlias(fmter, b)
+	}
+
+	// TABLESAMPLE follows the first table, i.e. the model table or the first TableExpr.
+	withModel := q.modelHasTableName()
+	first, rest := q.tables[:0], q.tables
+	if !withModel {
+		first, rest = q.tables[:1], q.tables[1:]
+	}
+	b, err = q.appendTableList(fmter, b, withModel, first, true)
+	if err != nil {
+		return nil, err
+	}
+
+	b = append(b, " TABLESAMPLE "...)
+	b, err = q.tableSample.AppendQuery(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range rest {
+		b = append(b, ", "...)
+		b, err = table.AppendQuery(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
 }
 
 func (q *SelectQuery) appendOrder(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if q.cursorField != "" && len(q.order) == 0 {
+		b = append(b, " ORDER BY "...)
+		b = fmter.AppendIdent(b, q.cursorField)
+		if q.cursorBefore {
+			return append(b, " DESC"...), nil
+		}
+		return append(b, " ASC"...), nil
+	}
+
 	if len(q.order) > 0 {
 		b = append(b, " ORDER BY "...)
 
@@ -621,10 +1732,18 @@ func (q *SelectQuery) appendOrder(fmter schema.Formatter, b []byte) (_ []byte, e
 			if i > 0 {
 				b = append(b, ", "...)
 			}
+
+			start := len(b)
 			b, err = f.AppendQuery(fmter, b)
 			if err != nil {
 				return nil, err
 			}
+
+			if i == 0 && q.cursorField != "" {
+				if err := q.checkCursorOrder(string(b[start:])); err != nil {
+					return nil, err
+				}
+			}
 		}
 
 		return b, nil
@@ -632,28 +1751,134 @@ func (q *SelectQuery) appendOrder(fmter schema.Formatter, b []byte) (_ []byte, e
 	return b, nil
 }
 
+// checkCursorOrder checks that the query is ordered by the cursor column
+// in the direction AfterCursor or BeforeCursor and Cursor rely on.
+func (q *SelectQuery) checkCursorOrder(order string) error {
+	column, desc := parseOrder(order)
+	if cursorColumnName(column) == cursorColumnName(q.cursorField) && desc == q.cursorBefore {
+		return nil
+	}
+	if q.cursorBefore {
+		return fmt.Errorf("bun: BeforeCursor requires ORDER BY %s DESC", q.cursorField)
+	}
+	return fmt.Errorf("bun: AfterCursor requires ORDER BY %s ASC", q.cursorField)
+}
+
+func parseOrder(s string) (column string, desc bool) {
+	if i := strings.IndexByte(s, ','); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	upper := strings.ToUpper(s)
+	switch {
+	case strings.HasSuffix(upper, " DESC"):
+		return strings.TrimSpace(s[:len(s)-len(" DESC")]), true
+	case strings.HasSuffix(upper, " ASC"):
+		return strings.TrimSpace(s[:len(s)-len(" ASC")]), false
+	}
+	return s, false
+}
+
+// cursorColumnName returns the unquoted column name without the table alias.
+func cursorColumnName(s string) string {
+	if i := strings.LastIndexByte(s, '.'); i >= 0 {
+		s = s[i+1:]
+	}
+	return strings.Trim(s, "\"`[]")
+}
+
 //------------------------------------------------------------------------------
 
 func (q *SelectQuery) Rows(ctx context.Context) (*sql.Rows, error) {
-	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	rows, _, err := q.Rows2(ctx)
+	return rows, err
+}
+
+// Rows2 is like Rows, but also returns the query that was sent to the database.
+// The query is returned even if the database returns an error.
+func (q *SelectQuery) Rows2(ctx context.Context) (*sql.Rows, string, error) {
+	// The session setting can't be restored while the rows are open.
+	if q.lockTimeout != 0 && !q.db.features.Has(feature.SetLocal) {
+		return nil, "", errors.New("bun: Rows does not support LockTimeout on mysql " +
+			"(use Scan or ForEach)")
+	}
+
+	// Only the MySQL settings need to be restored.
+	if _, err := q.setLocalSettings(ctx); err != nil {
+		return nil, "", err
+	}
+	return q.queryRows(ctx, q)
+}
+
+// QueryRows is an alias for Rows.
+func (q *SelectQuery) QueryRows(ctx context.Context) (*sql.Rows, error) {
+	return q.Rows(ctx)
+}
+
+// ForEach executes the query and calls fn for each row. Iteration stops
+// as soon as fn returns an error and the error is returned. The rows are always
+// closed when ForEach returns, so fn must not retain them.
+func (q *SelectQuery) ForEach(ctx context.Context, fn func(rows *sql.Rows) error) error {
+	if q.table != nil {
+		if err := q.beforeSelectHook(ctx); err != nil {
+			return err
+		}
+	}
+
+	restore, err := q.setLocalSettings(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	query := internal.String(queryBytes)
-	return q.conn.QueryContext(ctx, query)
+	if err := q.forEachRow(ctx, fn); err != nil {
+		return restoreSettings(restore, err)
+	}
+	if err := restoreSettings(restore, nil); err != nil {
+		return err
+	}
+
+	if q.table != nil {
+		if err := q.afterSelectHook(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (q *SelectQuery) forEachRow(ctx context.Context, fn func(rows *sql.Rows) error) error {
+	rows, _, err := q.queryRows(ctx, q)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return rows.Close()
 }
 
 func (q *SelectQuery) Exec(ctx context.Context) (res sql.Result, err error) {
-	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	restore, err := q.setLocalSettings(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, restoreSettings(restore, err)
+	}
+
 	query := internal.String(queryBytes)
 
 	res, err = q.exec(ctx, q, query)
-	if err != nil {
+	if err := restoreSettings(restore, err); err != nil {
 		return nil, err
 	}
 
@@ -661,10 +1886,117 @@ func (q *SelectQuery) Exec(ctx context.Context) (res sql.Result, err error) {
 }
 
 func (q *SelectQuery) Scan(ctx context.Context, dest ...interface{}) error {
-	model, err := q.getModel(dest)
+	_, err := q.scanRows(ctx, dest)
+	return err
+}
+
+// ScanFirst selects at most one row and scans it into dest.
+// Unlike Scan, it returns sql.ErrNoRows for slice destinations too.
+func (q *SelectQuery) ScanFirst(ctx context.Context, dest ...interface{}) error {
+	n, err := q.Limit(1).scanRows(ctx, dest)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// First selects the row with the smallest primary key and scans it into dest
+// or, when dest is nil, into the model. The primary key columns are added
+// to the end of ORDER BY. It returns sql.ErrNoRows if there are no rows.
+// First does not modify the query, so it can be called on a shared base query.
+// Queries without a table model are executed like Scan.
+func (q *SelectQuery) First(ctx context.Context, dest interface{}) error {
+	return q.scanByPK(ctx, dest, "ASC")
+}
+
+// Last is like First, but selects the row with the largest primary key.
+func (q *SelectQuery) Last(ctx context.Context, dest interface{}) error {
+	return q.scanByPK(ctx, dest, "DESC")
+}
+
+func (q *SelectQuery) scanByPK(ctx context.Context, dest interface{}, dir string) error {
+	if q.err != nil {
+		return q.err
+	}
+
+	var args []interface{}
+	if dest != nil {
+		args = []interface{}{dest}
+	}
+
+	clone := q.Clone()
+	if clone.table == nil {
+		return clone.Scan(ctx, args...)
+	}
+
+	if err := clone.table.CheckPKs(); err != nil {
+		return err
+	}
+	for _, pk := range clone.table.PKs {
+		clone.OrderExpr("?.? "+dir, clone.table.SQLAlias, pk.SQLName)
+	}
+	return clone.ScanFirst(ctx, args...)
+}
+
+const defaultProgressInterval = 1000
+
+// ScanWithProgressInterval sets how often ScanWithProgress calls the progress
+// callback. The default is every 1000 rows.
+func (q *SelectQuery) ScanWithProgressInterval(rows int) *SelectQuery {
+	if rows < 1 {
+		q.setErr(fmt.Errorf("bun: invalid progress interval: %d", rows))
+		return q
+	}
+	q.progressInterval = int64(rows)
+	return q
+}
+
+// ScanWithProgress is like Scan, but calls progress with the number of rows
+// scanned so far every 1000 rows (see ScanWithProgressInterval) and, unless
+// it was just reported, with the total number of rows when the scan is complete. The callback runs
+// in the goroutine that scans the rows, so it should return quickly.
+// Destinations other than slices report only the total number of rows.
+func (q *SelectQuery) ScanWithProgress(
+	ctx context.Context, dest interface{}, progress func(n int64),
+) error {
+	if progress == nil {
+		return q.Scan(ctx, dest)
+	}
+
+	p := &scanProgress{
+		interval: q.progressInterval,
+		fn:       progress,
+	}
+	if p.interval == 0 {
+		p.interval = defaultProgressInterval
+	}
+
+	var args []interface{}
+	if dest != nil {
+		args = []interface{}{dest}
+	}
+
+	n, err := q.scanRows(withScanProgress(ctx, p), args)
 	if err != nil {
 		return err
 	}
+	// Only slice models count the rows while scanning them.
+	p.n = int64(n)
+	if p.n == 0 || p.n%p.interval != 0 {
+		progress(p.n)
+	}
+	return nil
+}
+
+// scanRows is like Scan, but also returns the number of scanned rows.
+func (q *SelectQuery) scanRows(ctx context.Context, dest []interface{}) (int, error) {
+	model, err := q.getModel(dest)
+	if err != nil {
+		return 0, err
+	}
 
 	if q.limit > 1 {
 		if model, ok := model.(interface{ SetCap(int) }); ok {
@@ -674,37 +2006,42 @@ func (q *SelectQuery) Scan(ctx context.Context, dest ...interface{}) error {
 
 	if q.table != nil {
 		if err := q.beforeSelectHook(ctx); err != nil {
-			return err
+			return 0, err
 		}
 	}
 
+	restore, err := q.setLocalSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
 	if err != nil {
-		return err
+		return 0, restoreSettings(restore, err)
 	}
 
 	query := internal.String(queryBytes)
 
 	res, err := q.scan(ctx, q, query, model, true)
-	if err != nil {
-		return err
+	if err := restoreSettings(restore, err); err != nil {
+		return 0, err
 	}
 
 	if res.n > 0 {
 		if tableModel, ok := model.(tableModel); ok {
 			if err := q.selectJoins(ctx, tableModel.GetJoins()); err != nil {
-				return err
+				return 0, err
 			}
 		}
 	}
 
 	if q.table != nil {
 		if err := q.afterSelectHook(ctx); err != nil {
-			return err
+			return 0, err
 		}
 	}
 
-	return nil
+	return res.n, nil
 }
 
 func (q *SelectQuery) beforeSelectHook(ctx context.Context) error {
@@ -728,7 +2065,7 @@ func (q *SelectQuery) afterSelectHook(ctx context.Context) error {
 func (q *SelectQuery) Count(ctx context.Context) (int, error) {
 	qq := countQuery{q}
 
-	queryBytes, err := qq.appendQuery(q.db.fmter, nil, true)
+	queryBytes, err := qq.appendQuery(q.db.fmter, nil, selectCount)
 	if err != nil {
 		return 0, err
 	}
@@ -736,31 +2073,135 @@ func (q *SelectQuery) Count(ctx context.Context) (int, error) {
 	query := internal.String(queryBytes)
 	ctx, event := q.db.beforeQuery(ctx, qq, query, nil)
 
+	conn, release, err := q.tenantConn(ctx)
+	if err != nil {
+		q.db.afterQuery(ctx, event, nil, err)
+		return 0, err
+	}
+	defer release()
+
 	var num int
-	err = q.conn.QueryRowContext(ctx, query).Scan(&num)
+	err = conn.QueryRowContext(ctx, query).Scan(&num)
+	if err != nil && q.canFallback(conn, qq, err) {
+		err = q.fallbackScanRow(ctx, qq, query, &num)
+	}
+	if err != nil {
+		err = newQueryError(err, qq, query)
+	}
 
 	q.db.afterQuery(ctx, event, nil, err)
 
 	return num, err
 }
 
+// Exists reports whether the query returns at least one row. It selects 1 instead
+// of the columns and ignores ORDER BY, LIMIT, and OFFSET.
+func (q *SelectQuery) Exists(ctx context.Context) (bool, error) {
+	if q.err != nil {
+		return false, q.err
+	}
+
+	if q.table != nil {
+		if err := q.beforeSelectHook(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	restore, err := q.setLocalSettings(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	exists, err := q.exists(ctx)
+	if err := restoreSettings(restore, err); err != nil {
+		return false, err
+	}
+
+	if q.table != nil {
+		if err := q.afterSelectHook(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	return exists, nil
+}
+
+func (q *SelectQuery) exists(ctx context.Context) (bool, error) {
+	qq := selectExistsQuery{q}
+
+	queryBytes, err := qq.appendExistsQuery(q.db.fmter, nil)
+	if err != nil {
+		return false, err
+	}
+
+	query := internal.String(queryBytes)
+	ctx, event := q.db.beforeQuery(ctx, qq, query, nil)
+
+	conn, release, err := q.tenantConn(ctx)
+	if err != nil {
+		q.db.afterQuery(ctx, event, nil, err)
+		return false, err
+	}
+	defer release()
+
+	var num int
+	err = conn.QueryRowContext(ctx, query).Scan(&num)
+	if err != nil && q.canFallback(conn, qq, err) {
+		err = q.fallbackScanRow(ctx, qq, query, &num)
+	}
+	if err == sql.ErrNoRows {
+		err = nil
+	} else if err != nil {
+		err = newQueryError(err, qq, query)
+	}
+
+	q.db.afterQuery(ctx, event, nil, err)
+
+	return num == 1, err
+}
+
+// appendExistsQuery appends SELECT 1 ... LIMIT 1. Union queries are wrapped
+// in a subquery, because the operands must select the same columns.
+func (q *SelectQuery) appendExistsQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if len(q.union) == 0 {
+		return q.appendQuery(fmter, b, selectExists)
+	}
+
+	b = append(b, "SELECT 1 FROM ("...)
+	b, err = q.appendQuery(fmter, b, selectRows)
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, ") AS _exists_wrapper LIMIT 1"...)
+	return b, nil
+}
+
 func (q *SelectQuery) ScanAndCount(ctx context.Context, dest ...interface{}) (int, error) {
+	// Cancel the other query as soon as one of them fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	var count int
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var firstErr error
 
+	setErr := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+		mu.Unlock()
+	}
+
 	if q.limit >= 0 {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 
 			if err := q.Scan(ctx, dest...); err != nil {
-				mu.Lock()
-				if firstErr == nil {
-					firstErr = err
-				}
-				mu.Unlock()
+				setErr(err)
 			}
 		}()
 	}
@@ -772,11 +2213,7 @@ func (q *SelectQuery) ScanAndCount(ctx context.Context, dest ...interface{}) (in
 		var err error
 		count, err = q.Count(ctx)
 		if err != nil {
-			mu.Lock()
-			if firstErr == nil {
-				firstErr = err
-			}
-			mu.Unlock()
+			setErr(err)
 		}
 	}()
 
@@ -784,11 +2221,83 @@ func (q *SelectQuery) ScanAndCount(ctx context.Context, dest ...interface{}) (in
 	return count, firstErr
 }
 
+// Pagination describes a page of results returned by ScanPage.
+type Pagination struct {
+	Total      int
+	Page       int
+	PerPage    int
+	TotalPages int
+	HasNext    bool
+	HasPrev    bool
+}
+
+// ScanPage selects the page (starting from 1) with perPage rows
+// and counts the total number of rows using ScanAndCount.
+func (q *SelectQuery) ScanPage(
+	ctx context.Context, page, perPage int, dest ...interface{},
+) (Pagination, error) {
+	if page < 1 {
+		return Pagination{}, fmt.Errorf("bun: page must be >= 1, got %d", page)
+	}
+	if perPage < 1 {
+		return Pagination{}, fmt.Errorf("bun: perPage must be >= 1, got %d", perPage)
+	}
+
+	q.Limit(perPage).Offset((page - 1) * perPage)
+
+	total, err := q.ScanAndCount(ctx, dest...)
+	if err != nil {
+		return Pagination{}, err
+	}
+
+	totalPages := (total + perPage - 1) / perPage
+	return Pagination{
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}, nil
+}
+
+// FetchResult describes the rows selected by Fetch.
+type FetchResult struct {
+	Total   int
+	HasNext bool
+	HasPrev bool
+}
+
+// Fetch scans the rows selected with Limit and Offset into dest and counts
+// the total number of rows using ScanAndCount. HasNext reports whether there are
+// rows after the limit and HasPrev whether the offset skips any rows.
+func (q *SelectQuery) Fetch(ctx context.Context, dest interface{}) (FetchResult, error) {
+	var args []interface{}
+	if dest != nil {
+		args = []interface{}{dest}
+	}
+
+	total, err := q.ScanAndCount(ctx, args...)
+	if err != nil {
+		return FetchResult{}, err
+	}
+
+	return FetchResult{
+		Total:   total,
+		HasNext: q.limit > 0 && total > int(q.offset)+int(q.limit),
+		HasPrev: q.offset > 0,
+	}, nil
+}
+
 //------------------------------------------------------------------------------
 
 type joinQuery struct {
 	join schema.QueryWithArgs
 	on   []schema.QueryWithSep
+
+	lateral  bool
+	subquery schema.QueryAppender
+	alias    string
 }
 
 func (j *joinQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
@@ -799,6 +2308,20 @@ func (j *joinQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err
 		return nil, err
 	}
 
+	if j.lateral {
+		b = append(b, " ("...)
+		b, err = j.subquery.AppendQuery(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, ") AS "...)
+		b = fmter.AppendIdent(b, j.alias)
+
+		if len(j.on) == 0 {
+			b = append(b, " ON TRUE"...)
+		}
+	}
+
 	if len(j.on) > 0 {
 		b = append(b, " ON "...)
 		for i, on := range j.on {
@@ -825,5 +2348,15 @@ type countQuery struct {
 }
 
 func (q countQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
-	return q.appendQuery(formatterWithModel(fmter, q), b, true)
+	return q.appendQuery(formatterWithModel(fmter, q), b, selectCount)
+}
+
+//------------------------------------------------------------------------------
+
+type selectExistsQuery struct {
+	*SelectQuery
+}
+
+func (q selectExistsQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	return q.appendExistsQuery(formatterWithModel(fmter, q), b)
 }
@@ -3,6 +3,7 @@ package bun
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"sort"
 	"strconv"
 
@@ -20,6 +21,7 @@ type CreateTableQuery struct {
 	varchar     int
 
 	fks         []schema.QueryWithArgs
+	inherits    schema.QueryWithArgs
 	partitionBy schema.QueryWithArgs
 	tablespace  schema.QueryWithArgs
 }
@@ -85,6 +87,17 @@ func (q *CreateTableQuery) ForeignKey(query string, args ...interface{}) *Create
 	return q
 }
 
+// InheritsFrom appends `INHERITS (parent)` to the query.
+// It is only supported by PostgreSQL.
+func (q *CreateTableQuery) InheritsFrom(parent string) *CreateTableQuery {
+	if !q.db.features.Has(feature.TableInherits) {
+		q.setErr(errors.New("bun: INHERITS is not supported by the current dialect"))
+		return q
+	}
+	q.inherits = schema.UnsafeIdent(parent)
+	return q
+}
+
 func (q *CreateTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
 	if q.err != nil {
 		return nil, q.err
@@ -137,6 +150,15 @@ func (q *CreateTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []by
 
 	b = append(b, ")"...)
 
+	if !q.inherits.IsZero() {
+		b = append(b, " INHERITS ("...)
+		b, err = q.inherits.AppendQuery(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, ")"...)
+	}
+
 	if !q.partitionBy.IsZero() {
 		b = append(b, " PARTITION BY "...)
 		b, err = q.partitionBy.AppendQuery(fmter, b)
@@ -235,6 +257,10 @@ func (q *CreateTableQuery) Exec(ctx context.Context, dest ...interface{}) (sql.R
 		return nil, err
 	}
 
+	if err := q.createEnumTypes(ctx); err != nil {
+		return nil, err
+	}
+
 	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
 	if err != nil {
 		return nil, err
@@ -256,6 +282,34 @@ func (q *CreateTableQuery) Exec(ctx context.Context, dest ...interface{}) (sql.R
 	return res, nil
 }
 
+// createEnumTypes creates the enum types registered with the dialect
+// for fields tagged with `bun:",pgenum:name"`.
+func (q *CreateTableQuery) createEnumTypes(ctx context.Context) error {
+	d, ok := q.db.dialect.(enumDialect)
+	if !ok || q.table == nil {
+		return nil
+	}
+
+	for _, name := range enumTypeNames(q.table) {
+		values, ok := d.EnumValues(name)
+		if !ok {
+			continue
+		}
+
+		query := "CREATE TYPE ? AS ENUM (?)"
+		if q.ifNotExists {
+			query = "DO $$ BEGIN " + query +
+				"; EXCEPTION WHEN duplicate_object THEN NULL; END $$"
+		}
+		query = q.db.format(query, []interface{}{Ident(name), In(values)})
+
+		if _, err := q.exec(ctx, q, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (q *CreateTableQuery) beforeCreateTableHook(ctx context.Context) error {
 	if hook, ok := q.table.ZeroIface.(BeforeCreateTableHook); ok {
 		if err := hook.BeforeCreateTable(ctx, q); err != nil {
@@ -273,3 +327,28 @@ func (q *CreateTableQuery) afterCreateTableHook(ctx context.Context) error {
 	}
 	return nil
 }
+
+//------------------------------------------------------------------------------
+
+// enumDialect is implemented by dialects that support enum types, i.e. pgdialect.
+type enumDialect interface {
+	EnumValues(name string) ([]string, bool)
+}
+
+// enumTypeNames returns the names of the enum types used by the table.
+func enumTypeNames(table *schema.Table) []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, field := range table.Fields {
+		name, ok := field.Tag.Options["pgenum"]
+		if !ok {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names
+}
@@ -12,7 +12,8 @@ type DropTableQuery struct {
 	baseQuery
 	cascadeQuery
 
-	ifExists bool
+	ifExists  bool
+	enumTypes bool
 }
 
 func NewDropTableQuery(db *DB) *DropTableQuery {
@@ -61,6 +62,13 @@ func (q *DropTableQuery) Restrict() *DropTableQuery {
 	return q
 }
 
+// WithEnumTypes also drops the enum types registered with the dialect
+// that are used by the model fields tagged with `bun:",pgenum:name"`.
+func (q *DropTableQuery) WithEnumTypes() *DropTableQuery {
+	q.enumTypes = true
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 func (q *DropTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
@@ -104,6 +112,12 @@ func (q *DropTableQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Res
 		return nil, err
 	}
 
+	if q.enumTypes {
+		if err := q.dropEnumTypes(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	if q.table != nil {
 		if err := q.afterDropTableHook(ctx); err != nil {
 			return nil, err
@@ -113,6 +127,26 @@ func (q *DropTableQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Res
 	return res, nil
 }
 
+// dropEnumTypes drops the enum types registered with the dialect
+// for fields tagged with `bun:",pgenum:name"`.
+func (q *DropTableQuery) dropEnumTypes(ctx context.Context) error {
+	d, ok := q.db.dialect.(enumDialect)
+	if !ok || q.table == nil {
+		return nil
+	}
+
+	for _, name := range enumTypeNames(q.table) {
+		if _, ok := d.EnumValues(name); !ok {
+			continue
+		}
+		query := q.db.format("DROP TYPE IF EXISTS ?", []interface{}{Ident(name)})
+		if _, err := q.exec(ctx, q, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (q *DropTableQuery) beforeDropTableHook(ctx context.Context) error {
 	if hook, ok := q.table.ZeroIface.(BeforeDropTableHook); ok {
 		if err := hook.BeforeDropTable(ctx, q); err != nil {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/internal"
@@ -71,6 +72,82 @@ func (q *UpdateQuery) ModelTableExpr(query string, args ...interface{}) *UpdateQ
 	return q
 }
 
+// From adds a source table to the query. PostgreSQL and SQLite use
+// `UPDATE ... SET ... FROM table` and MySQL uses multi-table UPDATE syntax.
+func (q *UpdateQuery) From(table string, args ...interface{}) *UpdateQuery {
+	q.addTable(schema.SafeQuery(table, args))
+	return q
+}
+
+// FromModel adds the model table as a source table and updates columns
+// that exist in both tables matching rows by primary keys, for example,
+// `UPDATE users AS user SET name = src.name FROM src_users AS src WHERE user.id = src.id`.
+// It must be called after Model.
+func (q *UpdateQuery) FromModel(model interface{}) *UpdateQuery {
+	if q.table == nil {
+		q.setErr(errNilModel)
+		return q
+	}
+
+	typ := indirectType(reflect.TypeOf(model))
+	if typ.Kind() == reflect.Slice {
+		typ = indirectType(typ.Elem())
+	}
+	if typ.Kind() != reflect.Struct {
+		q.setErr(fmt.Errorf("bun: FromModel requires a struct, got %T", model))
+		return q
+	}
+	src := q.db.Table(typ)
+
+	q.addTable(schema.SafeQuery("? AS ?", []interface{}{src.SQLName, src.SQLAlias}))
+
+	var set, where []byte
+	for _, f := range q.table.DataFields {
+		srcField, ok := src.FieldMap[f.Name]
+		if !ok {
+			continue
+		}
+
+		if len(set) > 0 {
+			set = append(set, ", "...)
+		}
+		if q.db.fmter.HasFeature(feature.UpdateMultiTable) {
+			set = append(set, q.table.SQLAlias...)
+			set = append(set, '.')
+		}
+		set = append(set, f.SQLName...)
+		set = append(set, " = "...)
+		set = append(set, src.SQLAlias...)
+		set = append(set, '.')
+		set = append(set, srcField.SQLName...)
+	}
+	if len(set) == 0 {
+		q.setErr(fmt.Errorf("bun: %s and %s do not have common columns", q.table, src))
+		return q
+	}
+
+	for _, pk := range q.table.PKs {
+		srcField, ok := src.FieldMap[pk.Name]
+		if !ok {
+			q.setErr(fmt.Errorf("bun: %s does not have column=%q", src, pk.Name))
+			return q
+		}
+
+		if len(where) > 0 {
+			where = append(where, " AND "...)
+		}
+		where = append(where, q.table.SQLAlias...)
+		where = append(where, '.')
+		where = append(where, pk.SQLName...)
+		where = append(where, " = "...)
+		where = append(where, src.SQLAlias...)
+		where = append(where, '.')
+		where = append(where, srcField.SQLName...)
+	}
+
+	return q.Set(internal.String(set)).Where(internal.String(where))
+}
+
 //------------------------------------------------------------------------------
 
 func (q *UpdateQuery) Column(columns ...string) *UpdateQuery {
@@ -80,6 +157,30 @@ func (q *UpdateQuery) Column(columns ...string) *UpdateQuery {
 	return q
 }
 
+// Only restricts the SET clause generated from the model to the columns
+// regardless of the field values. Unlike Column, it must be called after Model
+// and it reports unknown columns and primary keys as errors.
+func (q *UpdateQuery) Only(columns ...string) *UpdateQuery {
+	if q.table == nil {
+		q.setErr(errors.New("bun: Only requires a struct or slice-based model"))
+		return q
+	}
+
+	for _, column := range columns {
+		field, err := q.table.Field(column)
+		if err != nil {
+			q.setErr(err)
+			return q
+		}
+		if field.IsPK {
+			q.setErr(fmt.Errorf("bun: Only can't update primary key %s", column))
+			return q
+		}
+	}
+
+	return q.Column(columns...)
+}
+
 func (q *UpdateQuery) ExcludeColumn(columns ...string) *UpdateQuery {
 	q.excludeColumn(columns)
 	return q
@@ -107,6 +208,53 @@ func (q *UpdateQuery) WherePK() *UpdateQuery {
 	return q
 }
 
+// WhereModel adds a condition that matches the rows with the same primary keys
+// as the models in the slice, e.g. `id IN (1, 2)` or `(a, b) IN ((1, 2), (3, 4))`
+// for composite primary keys.
+func (q *UpdateQuery) WhereModel(models interface{}) *UpdateQuery {
+	v := reflect.ValueOf(models)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice || indirectType(v.Type().Elem()).Kind() != reflect.Struct {
+		q.setErr(fmt.Errorf("bun: WhereModel(unsupported %T)", models))
+		return q
+	}
+	if v.Len() == 0 {
+		q.setErr(errors.New("bun: WhereModel got an empty slice"))
+		return q
+	}
+
+	table := q.db.Table(indirectType(v.Type().Elem()))
+	if err := table.CheckPKs(); err != nil {
+		q.setErr(err)
+		return q
+	}
+
+	q.addWhere(schema.SafeQueryWithSep("?", []interface{}{&whereModelPKs{
+		q:     q,
+		table: table,
+		slice: v,
+	}}, " AND "))
+	return q
+}
+
+type whereModelPKs struct {
+	q     *UpdateQuery
+	table *schema.Table
+	slice reflect.Value
+}
+
+var _ schema.QueryAppender = (*whereModelPKs)(nil)
+
+func (w *whereModelPKs) AppendQuery(fmter schema.Formatter, b []byte) ([]byte, error) {
+	var alias schema.Safe
+	if fmter.HasFeature(feature.UpdateMultiTable) && w.q.table != nil {
+		alias = w.q.table.SQLAlias
+	}
+	return appendPKsIn(fmter, b, w.table, alias, w.slice), nil
+}
+
 func (q *UpdateQuery) Where(query string, args ...interface{}) *UpdateQuery {
 	q.addWhere(schema.SafeQueryWithSep(query, args, " AND "))
 	return q
@@ -201,7 +349,7 @@ func (q *UpdateQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 	}
 
 	if len(q.returning) > 0 {
-		b, err = q.appendReturning(fmter, b)
+		b, err = q.appendReturning(fmter, b, q.db, q.table)
 		if err != nil {
 			return nil, err
 		}
@@ -361,6 +509,13 @@ func (db *UpdateQuery) updateSliceWhere(model *sliceTableModel) string {
 
 //------------------------------------------------------------------------------
 
+// QueryRows executes the query and returns the rows without scanning them,
+// which is useful together with a RETURNING clause. Model hooks are not called.
+func (q *UpdateQuery) QueryRows(ctx context.Context) (*sql.Rows, error) {
+	rows, _, err := q.queryRows(ctx, q)
+	return rows, err
+}
+
 func (q *UpdateQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
 	if q.table != nil {
 		if err := q.beforeUpdateHook(ctx); err != nil {
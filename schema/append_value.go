@@ -20,8 +20,9 @@ var (
 	ipNetType          = reflect.TypeOf((*net.IPNet)(nil)).Elem()
 	jsonRawMessageType = reflect.TypeOf((*json.RawMessage)(nil)).Elem()
 
-	driverValuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
-	queryAppenderType = reflect.TypeOf((*QueryAppender)(nil)).Elem()
+	driverValuerType         = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	driverValueConverterType = reflect.TypeOf((*driver.ValueConverter)(nil)).Elem()
+	queryAppenderType        = reflect.TypeOf((*QueryAppender)(nil)).Elem()
 )
 
 type (
@@ -76,6 +77,9 @@ func Appender(typ reflect.Type, custom CustomAppender) AppenderFunc {
 	if typ.Implements(driverValuerType) {
 		return driverValueAppender(custom)
 	}
+	if typ.Implements(driverValueConverterType) {
+		return valueConverterAppender(custom)
+	}
 
 	kind := typ.Kind()
 
@@ -87,6 +91,9 @@ func Appender(typ reflect.Type, custom CustomAppender) AppenderFunc {
 		if ptr.Implements(driverValuerType) {
 			return addrAppender(driverValueAppender(custom), custom)
 		}
+		if ptr.Implements(driverValueConverterType) {
+			return addrAppender(valueConverterAppender(custom), custom)
+		}
 	}
 
 	switch kind {
@@ -226,6 +233,22 @@ func appendDriverValue(fmter Formatter, b []byte, v driver.Valuer, custom Custom
 	return Append(fmter, b, value, custom)
 }
 
+// valueConverterAppender appends the value returned by ConvertValue
+// for types that implement driver.ValueConverter, but not driver.Valuer.
+func valueConverterAppender(custom CustomAppender) AppenderFunc {
+	return func(fmter Formatter, b []byte, v reflect.Value) []byte {
+		value, err := v.Interface().(driver.ValueConverter).ConvertValue(v.Interface())
+		if err != nil {
+			return dialect.AppendError(b, err)
+		}
+		if value != nil && reflect.TypeOf(value) == v.Type() {
+			err := fmt.Errorf("bun: %s.ConvertValue returned the same type", v.Type())
+			return dialect.AppendError(b, err)
+		}
+		return Append(fmter, b, value, custom)
+	}
+}
+
 func addrAppender(fn AppenderFunc, custom CustomAppender) AppenderFunc {
 	return func(fmter Formatter, b []byte, v reflect.Value) []byte {
 		if !v.CanAddr() {
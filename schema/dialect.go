@@ -9,11 +9,17 @@ import (
 	"github.com/uptrace/bun/dialect/feature"
 )
 
+// Feature is a set of optional SQL features, for example, feature.Returning.
+// The constants are defined in the dialect/feature package.
+type Feature = feature.Feature
+
 type Dialect interface {
 	Init(db *sql.DB)
 
 	Name() dialect.Name
 	Features() feature.Feature
+	// SupportsFeature reports whether the dialect supports all features in f.
+	SupportsFeature(f Feature) bool
 
 	Tables() *Tables
 	OnTable(table *Table)
@@ -33,10 +39,29 @@ type nopDialect struct {
 
 	appenderMap sync.Map
 	scannerMap  sync.Map
+
+	// template is true when the dialect is used to format query templates,
+	// i.e. queries with placeholders instead of args.
+	template bool
 }
 
-func newNopDialect() *nopDialect {
+// NewNopDialect returns a dialect that uses ANSI SQL quoting and does not
+// require a database connection. It is useful to test query generation.
+func NewNopDialect() Dialect {
+	return newNopDialect(false)
+}
+
+var defaultNopDialect = NewNopDialect()
+
+// DefaultNopDialect returns the nop dialect that is shared by the packages
+// that use models without a DB, so the tables are created only once.
+func DefaultNopDialect() Dialect {
+	return defaultNopDialect
+}
+
+func newNopDialect(template bool) *nopDialect {
 	d := new(nopDialect)
+	d.template = template
 	d.tables = NewTables(d)
 	d.features = feature.Returning
 	return d
@@ -52,6 +77,10 @@ func (d *nopDialect) Features() feature.Feature {
 	return d.features
 }
 
+func (d *nopDialect) SupportsFeature(f Feature) bool {
+	return d.features.Has(f)
+}
+
 func (d *nopDialect) Tables() *Tables {
 	return d.tables
 }
@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -35,12 +36,25 @@ type Field struct {
 	Append AppenderFunc
 	Scan   ScannerFunc
 	IsZero IsZeroerFunc
+
+	contextScanner bool
 }
 
 func (f *Field) String() string {
 	return f.Name
 }
 
+// GoTypeName returns the package-qualified name of the field type without
+// the pointer, e.g. int64, time.Time, or uuid.UUID.
+func (f *Field) GoTypeName() string {
+	return f.IndirectType.String()
+}
+
+// IsPointer reports whether the struct field is a pointer.
+func (f *Field) IsPointer() bool {
+	return f.StructField.Type.Kind() == reflect.Ptr
+}
+
 func (f *Field) Clone() *Field {
 	cp := *f
 	cp.Index = cp.Index[:len(f.Index):len(f.Index)]
@@ -98,6 +112,40 @@ func (f *Field) ScanValue(strct reflect.Value, src interface{}) error {
 	return f.ScanWithCheck(fv, src)
 }
 
+// ScanValueContext is like ScanValue, but passes the ctx to the field
+// if the field implements ContextScanner.
+func (f *Field) ScanValueContext(ctx context.Context, strct reflect.Value, src interface{}) error {
+	if !f.contextScanner {
+		return f.ScanValue(strct, src)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if src == nil {
+		fv, ok := fieldByIndex(strct, f.Index)
+		if !ok {
+			return nil
+		}
+		if fv.Kind() == reflect.Ptr {
+			if !fv.IsNil() {
+				fv.Set(reflect.Zero(fv.Type()))
+			}
+			return nil
+		}
+		return fv.Addr().Interface().(ContextScanner).ScanContext(ctx, nil)
+	}
+
+	fv := fieldByIndexAlloc(strct, f.Index)
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return fv.Interface().(ContextScanner).ScanContext(ctx, src)
+	}
+	return fv.Addr().Interface().(ContextScanner).ScanContext(ctx, src)
+}
+
 func (f *Field) markAsPK() {
 	f.IsPK = true
 	f.NotNull = true
@@ -32,7 +32,7 @@ func (args namedArgs) Get(name string) (interface{}, bool) {
 //------------------------------------------------------------------------------
 
 var nopFormatter = Formatter{
-	dialect: newNopDialect(),
+	dialect: newNopDialect(true),
 }
 
 type Formatter struct {
@@ -64,7 +64,8 @@ func (f Formatter) String() string {
 }
 
 func (f Formatter) IsNop() bool {
-	return f.dialect.Name() == dialect.Invalid
+	d, ok := f.dialect.(*nopDialect)
+	return ok && d.template
 }
 
 func (f Formatter) Dialect() Dialect {
@@ -104,6 +105,12 @@ func (f Formatter) WithModel(model NamedArgAppender) Formatter {
 	return clone
 }
 
+func (f Formatter) WithDialect(dialect Dialect) Formatter {
+	clone := f.clone()
+	clone.dialect = dialect
+	return clone
+}
+
 func (f Formatter) WithArg(name string, value interface{}) Formatter {
 	clone := f.clone()
 	clone.namedArgs = append(clone.namedArgs, namedArg{name: name, value: value})
@@ -139,7 +146,10 @@ func (f Formatter) append(dst []byte, p *parser.Parser, args []interface{}) []by
 		var ok bool
 		namedArgs, ok = args[0].(NamedArgAppender)
 		if !ok {
-			namedArgs, _ = newStructArgs(f, args[0])
+			// Don't store a nil *structArgs in the interface.
+			if structArgs, ok := newStructArgs(f, args[0]); ok {
+				namedArgs = structArgs
+			}
 		}
 	}
 
@@ -10,6 +10,7 @@ const (
 	BelongsToRelation
 	HasManyRelation
 	ManyToManyRelation
+	HasManyThroughRelation
 )
 
 type Relation struct {
@@ -22,6 +23,8 @@ type Relation struct {
 	PolymorphicField *Field
 	PolymorphicValue string
 
+	// M2MTable is a join table for ManyToManyRelation
+	// or a pivot table for HasManyThroughRelation.
 	M2MTable      *Table
 	M2MBaseFields []*Field
 	M2MJoinFields []*Field
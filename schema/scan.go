@@ -2,6 +2,7 @@ package schema
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
 	"net"
@@ -15,7 +16,17 @@ import (
 	"github.com/uptrace/bun/internal"
 )
 
-var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+var (
+	scannerType        = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	contextScannerType = reflect.TypeOf((*ContextScanner)(nil)).Elem()
+	rawBytesType       = reflect.TypeOf((*sql.RawBytes)(nil)).Elem()
+)
+
+// ContextScanner is like sql.Scanner, but also accepts the query context.
+// When a model field implements ContextScanner, it is used instead of sql.Scanner.
+type ContextScanner interface {
+	ScanContext(ctx context.Context, src interface{}) error
+}
 
 type ScannerFunc func(dest reflect.Value, src interface{}) error
 
@@ -54,6 +65,14 @@ func FieldScanner(dialect Dialect, field *Field) ScannerFunc {
 	if field.Tag.HasOption("json_use_number") {
 		return scanJSONUseNumber
 	}
+	if field.Tag.HasOption("rawbytes") {
+		if typ := field.IndirectType; typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+			if field.StructField.Type.Kind() == reflect.Ptr {
+				return ptrScanner(scanRawBytes)
+			}
+			return scanRawBytes
+		}
+	}
 	return dialect.Scanner(field.StructField.Type)
 }
 
@@ -86,6 +105,8 @@ func Scanner(typ reflect.Type) ScannerFunc {
 		return scanIPNet
 	case jsonRawMessageType:
 		return scanJSONRawMessage
+	case rawBytesType:
+		return scanRawBytes
 	}
 
 	return scanners[kind]
@@ -332,6 +353,35 @@ func scanJSONRawMessage(dest reflect.Value, src interface{}) error {
 	return nil
 }
 
+// scanRawBytes sets dest to the bytes returned by the driver without copying them.
+// Like sql.RawBytes, the bytes are only valid until the next call to Rows.Next,
+// Rows.Scan, or Rows.Close, because the driver may reuse the memory.
+// Values that are not returned as bytes, including strings, are copied
+// or formatted like database/sql does.
+func scanRawBytes(dest reflect.Value, src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		dest.SetBytes(nil)
+	case []byte:
+		dest.SetBytes(src)
+	case string:
+		dest.SetBytes([]byte(src))
+	case int64:
+		dest.SetBytes(strconv.AppendInt(nil, src, 10))
+	case uint64:
+		dest.SetBytes(strconv.AppendUint(nil, src, 10))
+	case float64:
+		dest.SetBytes(strconv.AppendFloat(nil, src, 'g', -1, 64))
+	case bool:
+		dest.SetBytes(strconv.AppendBool(nil, src))
+	case time.Time:
+		dest.SetBytes(src.AppendFormat(nil, time.RFC3339Nano))
+	default:
+		return fmt.Errorf("bun: can't scan %#v into %s", src, dest.Type())
+	}
+	return nil
+}
+
 func addrScanner(fn ScannerFunc) ScannerFunc {
 	return func(dest reflect.Value, src interface{}) error {
 		if !dest.CanAddr() {
@@ -35,6 +35,8 @@ func (s Ident) AppendQuery(fmter Formatter, b []byte) ([]byte, error) {
 type QueryWithArgs struct {
 	Query string
 	Args  []interface{}
+
+	argsFn func() []interface{}
 }
 
 var _ QueryAppender = QueryWithArgs{}
@@ -46,6 +48,12 @@ func SafeQuery(query string, args []interface{}) QueryWithArgs {
 	return QueryWithArgs{Query: query, Args: args}
 }
 
+// LazyQuery is like SafeQuery, but the args are returned by the fn
+// each time the query is formatted, for example, to use the current time.
+func LazyQuery(query string, fn func() []interface{}) QueryWithArgs {
+	return QueryWithArgs{Query: query, Args: make([]interface{}, 0), argsFn: fn}
+}
+
 func UnsafeIdent(ident string) QueryWithArgs {
 	return QueryWithArgs{Query: ident}
 }
@@ -58,6 +66,9 @@ func (q QueryWithArgs) AppendQuery(fmter Formatter, b []byte) ([]byte, error) {
 	if q.Args == nil {
 		return fmter.AppendIdent(b, q.Query), nil
 	}
+	if q.argsFn != nil {
+		return fmter.AppendQuery(b, q.Query, q.argsFn()...), nil
+	}
 	return fmter.AppendQuery(b, q.Query, q.Args...), nil
 }
 
@@ -3,6 +3,7 @@ package schema
 import (
 	"bytes"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -20,6 +21,11 @@ var (
 	nullFloatType   = reflect.TypeOf((*sql.NullFloat64)(nil)).Elem()
 	nullIntType     = reflect.TypeOf((*sql.NullInt64)(nil)).Elem()
 	nullStringType  = reflect.TypeOf((*sql.NullString)(nil)).Elem()
+
+	bunNullBoolType   = reflect.TypeOf((*NullBool)(nil)).Elem()
+	bunNullFloatType  = reflect.TypeOf((*NullFloat64)(nil)).Elem()
+	bunNullIntType    = reflect.TypeOf((*NullInt64)(nil)).Elem()
+	bunNullStringType = reflect.TypeOf((*NullString)(nil)).Elem()
 )
 
 var sqlTypes = []string{
@@ -55,13 +61,13 @@ func DiscoverSQLType(typ reflect.Type) string {
 	switch typ {
 	case timeType, nullTimeType, bunNullTimeType:
 		return sqltype.Timestamp
-	case nullBoolType:
+	case nullBoolType, bunNullBoolType:
 		return sqltype.Boolean
-	case nullFloatType:
+	case nullFloatType, bunNullFloatType:
 		return sqltype.DoublePrecision
-	case nullIntType:
+	case nullIntType, bunNullIntType:
 		return sqltype.BigInt
-	case nullStringType:
+	case nullStringType, bunNullStringType:
 		return sqltype.VarChar
 	}
 	return sqlTypes[typ.Kind()]
@@ -127,3 +133,129 @@ func (tm *NullTime) Scan(src interface{}) error {
 		return fmt.Errorf("bun: can't scan %#v into NullTime", src)
 	}
 }
+
+//------------------------------------------------------------------------------
+
+// NullString is a sql.NullString that marshals to JSON as a string or null.
+type NullString struct {
+	sql.NullString
+}
+
+var (
+	_ json.Marshaler   = (*NullString)(nil)
+	_ json.Unmarshaler = (*NullString)(nil)
+	_ sql.Scanner      = (*NullString)(nil)
+	_ driver.Valuer    = (*NullString)(nil)
+)
+
+func (n NullString) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.String)
+}
+
+func (n *NullString) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, jsonNull) {
+		n.String, n.Valid = "", false
+		return nil
+	}
+	if err := json.Unmarshal(b, &n.String); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// NullInt64 is a sql.NullInt64 that marshals to JSON as a number or null.
+type NullInt64 struct {
+	sql.NullInt64
+}
+
+var (
+	_ json.Marshaler   = (*NullInt64)(nil)
+	_ json.Unmarshaler = (*NullInt64)(nil)
+	_ sql.Scanner      = (*NullInt64)(nil)
+	_ driver.Valuer    = (*NullInt64)(nil)
+)
+
+func (n NullInt64) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Int64)
+}
+
+func (n *NullInt64) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, jsonNull) {
+		n.Int64, n.Valid = 0, false
+		return nil
+	}
+	if err := json.Unmarshal(b, &n.Int64); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// NullFloat64 is a sql.NullFloat64 that marshals to JSON as a number or null.
+type NullFloat64 struct {
+	sql.NullFloat64
+}
+
+var (
+	_ json.Marshaler   = (*NullFloat64)(nil)
+	_ json.Unmarshaler = (*NullFloat64)(nil)
+	_ sql.Scanner      = (*NullFloat64)(nil)
+	_ driver.Valuer    = (*NullFloat64)(nil)
+)
+
+func (n NullFloat64) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Float64)
+}
+
+func (n *NullFloat64) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, jsonNull) {
+		n.Float64, n.Valid = 0, false
+		return nil
+	}
+	if err := json.Unmarshal(b, &n.Float64); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// NullBool is a sql.NullBool that marshals to JSON as a boolean or null.
+type NullBool struct {
+	sql.NullBool
+}
+
+var (
+	_ json.Marshaler   = (*NullBool)(nil)
+	_ json.Unmarshaler = (*NullBool)(nil)
+	_ sql.Scanner      = (*NullBool)(nil)
+	_ driver.Valuer    = (*NullBool)(nil)
+)
+
+func (n NullBool) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return jsonNull, nil
+	}
+	return json.Marshal(n.Bool)
+}
+
+func (n *NullBool) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, jsonNull) {
+		n.Bool, n.Valid = false, false
+		return nil
+	}
+	if err := json.Unmarshal(b, &n.Bool); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jinzhu/inflection"
 
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/internal/tagparser"
 )
@@ -22,6 +23,9 @@ const (
 var (
 	baseModelType      = reflect.TypeOf((*BaseModel)(nil)).Elem()
 	tableNameInflector = inflection.Plural
+
+	nameMapper         = internal.Underscore
+	dialectNameMappers = make(map[dialect.Name]func(string) string)
 )
 
 type BaseModel struct{}
@@ -32,6 +36,35 @@ func SetTableNameInflector(fn func(string) string) {
 	tableNameInflector = fn
 }
 
+// RegisterNameMapper overrides the default func that converts struct field names
+// to column names, e.g. UserID becomes user_id. The mapper only applies to tables
+// that are created after it is registered, so register it before using any models.
+// Passing nil restores the default mapper.
+func RegisterNameMapper(fn func(goName string) string) {
+	if fn == nil {
+		fn = internal.Underscore
+	}
+	nameMapper = fn
+}
+
+// RegisterDialectNameMapper is like RegisterNameMapper, but only applies
+// to the dialect and takes precedence over the global mapper.
+// Passing nil removes the dialect mapper.
+func RegisterDialectNameMapper(name dialect.Name, fn func(goName string) string) {
+	if fn == nil {
+		delete(dialectNameMappers, name)
+		return
+	}
+	dialectNameMappers[name] = fn
+}
+
+func (t *Table) columnName(goName string) string {
+	if fn, ok := dialectNameMappers[t.dialect.Name()]; ok {
+		return fn(goName)
+	}
+	return nameMapper(goName)
+}
+
 // Table represents a SQL table created from Go struct.
 type Table struct {
 	dialect Dialect
@@ -62,8 +95,9 @@ type Table struct {
 	SoftDeleteField       *Field
 	UpdateSoftDeleteField func(fv reflect.Value) error
 
-	allFields     []*Field // read only
-	skippedFields []*Field
+	allFields      []*Field // read only
+	skippedFields  []*Field
+	computedFields []computedField
 
 	flags internal.Flag
 }
@@ -170,6 +204,26 @@ func (t *Table) Field(name string) (*Field, error) {
 	return field, nil
 }
 
+// SQLColumnNames returns the column names in the order the fields are defined
+// in the struct, e.g. []string{"id", "created_at"}.
+func (t *Table) SQLColumnNames() []string {
+	names := make([]string, len(t.Fields))
+	for i, f := range t.Fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// SQLColumnTypes returns the SQL types used by CREATE TABLE for the columns
+// in the same order as SQLColumnNames.
+func (t *Table) SQLColumnTypes() []string {
+	types := make([]string, len(t.Fields))
+	for i, f := range t.Fields {
+		types[i] = f.CreateTableSQLType
+	}
+	return types
+}
+
 func (t *Table) fieldByGoName(name string) *Field {
 	for _, f := range t.allFields {
 		if f.GoName == name {
@@ -288,7 +342,7 @@ func (t *Table) newField(f reflect.StructField, index []int) *Field {
 		return nil
 	}
 
-	sqlName := internal.Underscore(f.Name)
+	sqlName := t.columnName(f.Name)
 
 	if tag.Name != sqlName && isKnownFieldOption(tag.Name) {
 		internal.Warn.Printf(
@@ -352,9 +406,15 @@ func (t *Table) newField(f reflect.StructField, index []int) *Field {
 	if s, ok := field.Tag.Options["type"]; ok {
 		field.UserSQLType = s
 	}
+	if opt := dialectTypeOption(t.dialect.Name()); opt != "" {
+		if s, ok := field.Tag.Options[opt]; ok {
+			field.UserSQLType = s
+		}
+	}
 	field.DiscoveredSQLType = DiscoverSQLType(field.IndirectType)
 	field.Append = t.dialect.FieldAppender(field)
 	field.Scan = FieldScanner(t.dialect, field)
+	field.contextScanner = reflect.PtrTo(field.IndirectType).Implements(contextScannerType)
 	field.IsZero = FieldZeroChecker(field)
 
 	if v, ok := tag.Options["alt"]; ok {
@@ -362,6 +422,10 @@ func (t *Table) newField(f reflect.StructField, index []int) *Field {
 	}
 
 	t.allFields = append(t.allFields, field)
+	if _, ok := tag.Options["computed"]; ok {
+		t.addComputedField(field)
+		return nil
+	}
 	if skip {
 		t.skippedFields = append(t.skippedFields, field)
 		t.FieldMap[field.Name] = field
@@ -377,6 +441,54 @@ func (t *Table) newField(f reflect.StructField, index []int) *Field {
 	return field
 }
 
+type computedField struct {
+	field  *Field
+	method reflect.Method
+}
+
+// addComputedField registers a field that is set after scanning using the model method
+// specified in the tag, e.g. `bun:",computed:FullName"`. By default the method name
+// is Compute followed by the field name.
+//
+// Computed values are stored in tagged fields rather than declared with a marker type
+// embedded in the model, because a struct can't have a field and a method with the same
+// name and the marker would have no typed field to store the result of the method in.
+func (t *Table) addComputedField(field *Field) {
+	name := field.Tag.Options["computed"]
+	if name == "" {
+		name = "Compute" + field.GoName
+	}
+
+	method, ok := reflect.PtrTo(t.Type).MethodByName(name)
+	if !ok {
+		panic(fmt.Errorf("bun: %s does not have method %s for computed field %s",
+			t.TypeName, name, field.GoName))
+	}
+	// The receiver is the first argument.
+	if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 ||
+		!method.Type.Out(0).AssignableTo(field.StructField.Type) {
+		panic(fmt.Errorf("bun: %s.%s must have signature func() %s",
+			t.TypeName, name, field.StructField.Type))
+	}
+
+	t.computedFields = append(t.computedFields, computedField{
+		field:  field,
+		method: method,
+	})
+}
+
+func (t *Table) HasComputedFields() bool {
+	return len(t.computedFields) > 0
+}
+
+// ComputeFields sets computed fields of the struct by calling the corresponding methods.
+func (t *Table) ComputeFields(strct reflect.Value) {
+	for _, cf := range t.computedFields {
+		res := strct.Addr().Method(cf.method.Index).Call(nil)
+		cf.field.Value(strct).Set(res[0])
+	}
+}
+
 func (t *Table) initInlines() {
 	for _, f := range t.skippedFields {
 		if f.IndirectType.Kind() == reflect.Struct {
@@ -430,7 +542,11 @@ func (t *Table) initRelation(field *Field, rel string) {
 	case "has-one":
 		t.addRelation(t.hasOneRelation(field))
 	case "has-many":
-		t.addRelation(t.hasManyRelation(field))
+		if field.Tag.HasOption("through") {
+			t.addRelation(t.hasManyThroughRelation(field))
+		} else {
+			t.addRelation(t.hasManyRelation(field))
+		}
 	default:
 		panic(fmt.Errorf("bun: unknown relation=%s on field=%s", rel, field.GoName))
 	}
@@ -661,6 +777,84 @@ func (t *Table) hasManyRelation(field *Field) *Relation {
 	return rel
 }
 
+// hasManyThroughRelation creates a has-many relation that joins models through
+// a pivot model, for example, `rel:has-many,through:OrgMember,join_fk:org_id,join_ref:user_id`.
+// join_fk is the pivot column that references the base model and join_ref is the pivot
+// column that references the joined model.
+func (t *Table) hasManyThroughRelation(field *Field) *Relation {
+	if field.IndirectType.Kind() != reflect.Slice {
+		panic(fmt.Errorf(
+			"bun: %s.%s has-many relation requires slice, got %q",
+			t.TypeName, field.GoName, field.IndirectType.Kind(),
+		))
+	}
+	joinTable := t.dialect.Tables().Ref(indirectType(field.IndirectType.Elem()))
+
+	if len(t.PKs) != 1 {
+		panic(fmt.Errorf(
+			"bun: %s has-many %s: through option requires a single primary key on %s",
+			t.TypeName, field.GoName, t.TypeName,
+		))
+	}
+	if len(joinTable.PKs) != 1 {
+		panic(fmt.Errorf(
+			"bun: %s has-many %s: through option requires a single primary key on %s",
+			t.TypeName, field.GoName, joinTable.TypeName,
+		))
+	}
+
+	throughName := field.Tag.Options["through"]
+	throughTable := t.dialect.Tables().ByModel(throughName)
+	if throughTable == nil {
+		throughTable = t.dialect.Tables().ByName(throughName)
+	}
+	if throughTable == nil {
+		panic(fmt.Errorf(
+			"bun: can't find %s through model (use db.RegisterModel)",
+			throughName,
+		))
+	}
+
+	fkColumn, ok := field.Tag.Options["join_fk"]
+	if !ok {
+		fkColumn = internal.Underscore(t.ModelName) + "_" + t.PKs[0].Name
+	}
+	refColumn, ok := field.Tag.Options["join_ref"]
+	if !ok {
+		refColumn = internal.Underscore(joinTable.ModelName) + "_" + joinTable.PKs[0].Name
+	}
+
+	fkField := throughTable.fieldWithLock(fkColumn)
+	if fkField == nil {
+		panic(fmt.Errorf(
+			"bun: %s has-many %s: %s must have column %s "+
+				"(to override, use join_fk:column tag on the field %s)",
+			t.TypeName, field.GoName, throughTable.TypeName, fkColumn, field.GoName,
+		))
+	}
+	refField := throughTable.fieldWithLock(refColumn)
+	if refField == nil {
+		panic(fmt.Errorf(
+			"bun: %s has-many %s: %s must have column %s "+
+				"(to override, use join_ref:column tag on the field %s)",
+			t.TypeName, field.GoName, throughTable.TypeName, refColumn, field.GoName,
+		))
+	}
+
+	return &Relation{
+		Type:      HasManyThroughRelation,
+		Field:     field,
+		JoinTable: joinTable,
+
+		BaseFields: t.PKs,
+		JoinFields: joinTable.PKs,
+
+		M2MTable:      throughTable,
+		M2MBaseFields: []*Field{fkField},
+		M2MJoinFields: []*Field{refField},
+	}
+}
+
 func (t *Table) m2mRelation(field *Field) *Relation {
 	if field.IndirectType.Kind() != reflect.Slice {
 		panic(fmt.Errorf(
@@ -736,6 +930,9 @@ func (t *Table) m2mRelation(field *Field) *Relation {
 	return rel
 }
 
+// inlineFields adds the fields of the struct field to the FieldMap so they can be
+// scanned from columns like field__nested__column. Structs are inlined at any depth;
+// the path contains the struct types being inlined and is used to stop at cycles.
 func (t *Table) inlineFields(field *Field, path map[reflect.Type]struct{}) {
 	if path == nil {
 		path = map[reflect.Type]struct{}{
@@ -747,6 +944,8 @@ func (t *Table) inlineFields(field *Field, path map[reflect.Type]struct{}) {
 		return
 	}
 	path[field.IndirectType] = struct{}{}
+	// Sibling fields can have the same type, e.g. HomeAddress and WorkAddress.
+	defer delete(path, field.IndirectType)
 
 	joinTable := t.dialect.Tables().Ref(field.IndirectType)
 	for _, f := range joinTable.allFields {
@@ -762,11 +961,7 @@ func (t *Table) inlineFields(field *Field, path map[reflect.Type]struct{}) {
 		}
 		t.fieldsMapMu.Unlock()
 
-		if f.IndirectType.Kind() != reflect.Struct {
-			continue
-		}
-
-		if _, ok := path[f.IndirectType]; !ok {
+		if f.IndirectType.Kind() == reflect.Struct {
 			t.inlineFields(f, path)
 		}
 	}
@@ -812,13 +1007,33 @@ func isKnownTableOption(name string) bool {
 	return false
 }
 
+// dialectTypeOption returns the tag option that overrides the SQL type
+// for the dialect, e.g. `bun:",pg:bytea,mysql:BLOB"`.
+func dialectTypeOption(name dialect.Name) string {
+	switch name {
+	case dialect.PG:
+		return "pg"
+	case dialect.MySQL5, dialect.MySQL8:
+		return "mysql"
+	case dialect.SQLite:
+		return "sqlite"
+	}
+	return ""
+}
+
 func isKnownFieldOption(name string) bool {
 	switch name {
 	case "alias",
 		"type",
+		"pg",
+		"mysql",
+		"sqlite",
 		"array",
 		"hstore",
 		"composite",
+		"range",
+		"pgenum",
+		"computed",
 		"json_use_number",
 		"msgpack",
 		"notnull",
@@ -826,12 +1041,16 @@ func isKnownFieldOption(name string) bool {
 		"default",
 		"unique",
 		"soft_delete",
+		"acl",
 
 		"pk",
 		"autoincrement",
 		"rel",
 		"join",
 		"m2m",
+		"through",
+		"join_fk",
+		"join_ref",
 		"polymorphic":
 		return true
 	}
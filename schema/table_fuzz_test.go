@@ -0,0 +1,116 @@
+//go:build go1.18
+// +build go1.18
+
+package schema_test
+
+import (
+	"io"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+func FuzzTableParsing(f *testing.F) {
+	internal.Warn.SetOutput(io.Discard)
+
+	for _, tags := range [][2]string{
+		{"", ""},
+		{"table:users,alias:u", "name"},
+		{"users", ",pk,autoincrement"},
+		{`table:"users"`, `"name"`},
+		{"table:my.users", "a.b"},
+		{"table:x'y", "x\"y,type:varchar(100),default:'x'"},
+		{"alias:*", "*,nullzero"},
+	} {
+		f.Add(tags[0], tags[1])
+	}
+
+	f.Fuzz(func(t *testing.T, tableTag, fieldTag string) {
+		typ := reflect.StructOf([]reflect.StructField{
+			{
+				Name:      "BaseModel",
+				Type:      reflect.TypeOf(schema.BaseModel{}),
+				Tag:       reflect.StructTag("bun:" + strconv.Quote(tableTag)),
+				Anonymous: true,
+			},
+			{
+				Name: "ID",
+				Type: reflect.TypeOf(int64(0)),
+			},
+			{
+				Name: "Value",
+				Type: reflect.TypeOf(""),
+				Tag:  reflect.StructTag("bun:" + strconv.Quote(fieldTag)),
+			},
+		})
+
+		table, ok := newTable(typ)
+		if !ok {
+			return
+		}
+
+		checkIdent(t, table.SQLName)
+		checkIdent(t, table.SQLAlias)
+		for _, field := range table.Fields {
+			checkIdent(t, field.SQLName)
+		}
+	})
+}
+
+// newTable returns false if the tags are rejected with a panic.
+func newTable(typ reflect.Type) (table *schema.Table, ok bool) {
+	defer func() {
+		if v := recover(); v != nil {
+			if _, isRuntime := v.(runtime.Error); isRuntime {
+				panic(v)
+			}
+			if _, isErr := v.(error); !isErr {
+				panic(v)
+			}
+		}
+	}()
+	return schema.NewNopDialect().Tables().Get(typ), true
+}
+
+// checkIdent checks that everything except dots and stars is quoted.
+func checkIdent(t *testing.T, ident schema.Safe) {
+	s, ok := stripQuoted(string(ident))
+	if !ok || strings.Trim(s, ".*") != "" {
+		t.Fatalf("identifier is not quoted: %s", ident)
+	}
+}
+
+// stripQuoted removes quoted identifiers and string literals from the query.
+// It returns false if a quote is not closed.
+func stripQuoted(query string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(query); i++ {
+		quote := query[i]
+		if quote != '"' && quote != '\'' {
+			b.WriteByte(quote)
+			continue
+		}
+
+		closed := false
+		for i++; i < len(query); i++ {
+			if query[i] != quote {
+				continue
+			}
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			closed = true
+			break
+		}
+		if !closed {
+			return "", false
+		}
+	}
+	return b.String(), true
+}
@@ -0,0 +1,70 @@
+package bun
+
+import (
+	"context"
+	"fmt"
+)
+
+// Sharded routes queries to one of the databases (shards)
+// using the shard index returned by the shard func.
+type Sharded struct {
+	shards  []*DB
+	shardFn func(ctx context.Context) int
+}
+
+// NewSharded returns a Sharded that uses fn to select a shard from the context.
+func NewSharded(shards []*DB, fn func(ctx context.Context) int) *Sharded {
+	if len(shards) == 0 {
+		panic("bun: NewSharded requires at least one shard")
+	}
+	return &Sharded{
+		shards:  shards,
+		shardFn: fn,
+	}
+}
+
+// Shards returns all shards.
+func (s *Sharded) Shards() []*DB {
+	return s.shards
+}
+
+// Shard returns the shard selected by the shard func for the context.
+func (s *Sharded) Shard(ctx context.Context) *DB {
+	i := s.shardFn(ctx)
+	if i < 0 || i >= len(s.shards) {
+		panic(fmt.Errorf("bun: shard index %d is out of range [0, %d)", i, len(s.shards)))
+	}
+	return s.shards[i]
+}
+
+func (s *Sharded) NewValues(ctx context.Context, model interface{}) *ValuesQuery {
+	return s.Shard(ctx).NewValues(model)
+}
+
+func (s *Sharded) NewSelect(ctx context.Context) *SelectQuery {
+	return s.Shard(ctx).NewSelect()
+}
+
+func (s *Sharded) NewInsert(ctx context.Context) *InsertQuery {
+	return s.Shard(ctx).NewInsert()
+}
+
+func (s *Sharded) NewUpdate(ctx context.Context) *UpdateQuery {
+	return s.Shard(ctx).NewUpdate()
+}
+
+func (s *Sharded) NewDelete(ctx context.Context) *DeleteQuery {
+	return s.Shard(ctx).NewDelete()
+}
+
+// All calls fn for each shard in order and returns the first error.
+// Because shards are processed sequentially, fn can merge results
+// without additional synchronization.
+func (s *Sharded) All(ctx context.Context, fn func(ctx context.Context, db *DB) error) error {
+	for _, db := range s.shards {
+		if err := fn(ctx, db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/uptrace/bun/dialect/feature"
+)
+
+type tenantSchemaKey struct{}
+
+// WithTenantSchema returns a context that makes queries use the PostgreSQL
+// schema as the search_path. It applies to queries built with bun and to
+// the raw Exec, Query, and QueryRow methods of DB, Conn, and Tx.
+//
+// Queries executed in a transaction run SET LOCAL search_path before the query.
+// Other queries run on a connection that has its search_path set before
+// the query and reset afterwards, which costs two extra round trips per query.
+// Use a transaction to run several queries with the same schema.
+//
+// Rows, Query, and QueryRow outside of a transaction can't reset the connection
+// until the rows are closed, so they return an error.
+func WithTenantSchema(ctx context.Context, schema string) context.Context {
+	return context.WithValue(ctx, tenantSchemaKey{}, schema)
+}
+
+// TenantSchema returns the schema set with WithTenantSchema.
+func TenantSchema(ctx context.Context) (string, bool) {
+	schema, ok := ctx.Value(tenantSchemaKey{}).(string)
+	return schema, ok
+}
+
+var errTenantRows = errors.New("bun: querying rows with a tenant schema requires a transaction")
+
+// errRow returns a row that fails with the err, since sql.Row can't be
+// created outside of database/sql.
+func errRow(err error) *sql.Row {
+	db := sql.OpenDB(errConnector{err: err})
+	defer db.Close()
+	return db.QueryRowContext(context.Background(), "")
+}
+
+type errConnector struct {
+	err error
+}
+
+func (c errConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, c.err
+}
+
+func (c errConnector) Driver() driver.Driver {
+	return errDriver{err: c.err}
+}
+
+type errDriver struct {
+	err error
+}
+
+func (d errDriver) Open(string) (driver.Conn, error) {
+	return nil, d.err
+}
+
+func nopRelease() {}
+
+// tenantConn returns the connection to execute the query on and a func
+// that must be called once the query is done.
+func (db *DB) tenantConn(ctx context.Context, conn IConn) (IConn, func(), error) {
+	schema, ok := TenantSchema(ctx)
+	if !ok {
+		return conn, nopRelease, nil
+	}
+	if !db.features.Has(feature.SearchPath) {
+		return nil, nil, errors.New("bun: tenant schemas require PostgreSQL")
+	}
+
+	switch conn := conn.(type) {
+	case *sql.Tx:
+		query := db.format("SET LOCAL search_path = ?", []interface{}{Ident(schema)})
+		if _, err := conn.ExecContext(ctx, query); err != nil {
+			return nil, nil, err
+		}
+		return conn, nopRelease, nil
+	case *sql.DB:
+		cn, err := conn.Conn(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := db.setSearchPath(ctx, cn, schema); err != nil {
+			_ = cn.Close()
+			return nil, nil, err
+		}
+		return cn, func() {
+			db.resetSearchPath(cn)
+			_ = cn.Close()
+		}, nil
+	default:
+		if err := db.setSearchPath(ctx, conn, schema); err != nil {
+			return nil, nil, err
+		}
+		return conn, func() {
+			db.resetSearchPath(conn)
+		}, nil
+	}
+}
+
+func (db *DB) setSearchPath(ctx context.Context, conn IConn, schema string) error {
+	query := db.format("SET search_path = ?", []interface{}{Ident(schema)})
+	_, err := conn.ExecContext(ctx, query)
+	return err
+}
+
+func (db *DB) resetSearchPath(conn IConn) {
+	// The query context may already be canceled.
+	if _, err := conn.ExecContext(context.Background(), "RESET search_path"); err != nil {
+		// Don't return the connection to the pool with the tenant schema.
+		if cn, ok := conn.(*sql.Conn); ok {
+			_ = cn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}
+}
+
+// tenantConn returns the connection of the query with the tenant schema applied.
+func (q *baseQuery) tenantConn(ctx context.Context) (IConn, func(), error) {
+	return q.db.tenantConn(ctx, q.getConn())
+}